@@ -0,0 +1,173 @@
+package logger
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/outsidedigital/logger/buffer"
+)
+
+// Overflow represents a policy applied by the asynchronous writer when its
+// queue is full.
+type Overflow uint8
+
+// Well-known overflow policies.
+const (
+	// OverflowBlock blocks the caller until the queue has room for the entry.
+	OverflowBlock Overflow = iota
+	// OverflowDropNewest drops the entry that is being written.
+	OverflowDropNewest
+	// OverflowDropOldest drops the oldest queued entry to make room for the
+	// entry that is being written.
+	OverflowDropOldest
+)
+
+// AsyncWriter implements a logging writer that encodes entries on the caller's
+// goroutine and writes them to the output on a background goroutine.
+type AsyncWriter struct {
+	dropped  uint64
+	w        *FormatWriter
+	overflow Overflow
+	queue    chan *buffer.Buffer
+	flush    chan chan struct{}
+	done     chan struct{}
+	mu       sync.RWMutex
+	closed   bool
+}
+
+// NewAsyncWriter creates a new asynchronous writer that encodes entries with
+// the format of the given writer and passes them to its output through a queue
+// of the given size. Once the writer is no longer needed, it should be closed.
+func NewAsyncWriter(w *FormatWriter, size int, overflow Overflow) *AsyncWriter {
+	aw := &AsyncWriter{
+		w:        w,
+		overflow: overflow,
+		queue:    make(chan *buffer.Buffer, size),
+		flush:    make(chan chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go aw.run()
+	return aw
+}
+
+// Write encodes given fields and queues them to be written to the output.
+func (w *AsyncWriter) Write(ff ...Field) {
+	buf := writerPool.Get()
+	w.w.format(buf, ff...)
+	if buf.Len() == 0 {
+		writerPool.Put(buf)
+		return
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		w.drop(buf)
+		return
+	}
+
+	switch w.overflow {
+	case OverflowDropNewest:
+		select {
+		case w.queue <- buf:
+		default:
+			w.drop(buf)
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case w.queue <- buf:
+				return
+			default:
+			}
+			select {
+			case old := <-w.queue:
+				w.drop(old)
+			default:
+			}
+		}
+	default:
+		w.queue <- buf
+	}
+}
+
+// Dropped returns the number of entries dropped due to the queue overflow or
+// written after the writer was closed.
+func (w *AsyncWriter) Dropped() uint64 {
+	return atomic.LoadUint64(&w.dropped)
+}
+
+// Flush waits until entries queued before the call are written to the output.
+func (w *AsyncWriter) Flush() {
+	ack := make(chan struct{})
+	select {
+	case w.flush <- ack:
+		<-ack
+	case <-w.done:
+	}
+}
+
+// Sync writes entries queued before the call to the output and syncs it.
+func (w *AsyncWriter) Sync() error {
+	w.Flush()
+	return w.w.Sync()
+}
+
+// Close writes all queued entries to the output, stops the background
+// goroutine and closes the output. Entries written after this method is called
+// are dropped.
+func (w *AsyncWriter) Close() error {
+	w.mu.Lock()
+	closed := w.closed
+	if !closed {
+		w.closed = true
+		close(w.queue)
+	}
+	w.mu.Unlock()
+
+	<-w.done
+	if closed {
+		return nil
+	}
+	return w.w.Close()
+}
+
+func (w *AsyncWriter) run() {
+	defer close(w.done)
+	for {
+		select {
+		case buf, ok := <-w.queue:
+			if !ok {
+				return
+			}
+			w.write(buf)
+		case ack := <-w.flush:
+			w.drain()
+			close(ack)
+		}
+	}
+}
+
+func (w *AsyncWriter) drain() {
+	for {
+		select {
+		case buf, ok := <-w.queue:
+			if !ok {
+				return
+			}
+			w.write(buf)
+		default:
+			return
+		}
+	}
+}
+
+func (w *AsyncWriter) write(buf *buffer.Buffer) {
+	w.w.write(buf)
+	writerPool.Put(buf)
+}
+
+func (w *AsyncWriter) drop(buf *buffer.Buffer) {
+	atomic.AddUint64(&w.dropped, 1)
+	writerPool.Put(buf)
+}
@@ -0,0 +1,131 @@
+package logger
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// gatedWriter implements an output that holds writes until the gate opens and
+// signals each write it receives.
+type gatedWriter struct {
+	entered chan struct{}
+	gate    chan struct{}
+	mu      sync.Mutex
+	buf     bytes.Buffer
+}
+
+func newGatedWriter() *gatedWriter {
+	return &gatedWriter{entered: make(chan struct{}, 100), gate: make(chan struct{})}
+}
+
+func (w *gatedWriter) Write(p []byte) (int, error) {
+	w.entered <- struct{}{}
+	<-w.gate
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *gatedWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+func TestAsyncWriterOverflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		overflow Overflow
+		want     string
+	}{
+		{name: "drop newest", overflow: OverflowDropNewest, want: "0 1 2"},
+		{name: "drop oldest", overflow: OverflowDropOldest, want: "0 4 5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := newGatedWriter()
+			w := NewAsyncWriter(TextWriter(out), 2, tt.overflow)
+			w.Write(Message("0"))
+			<-out.entered
+			for i := 1; i < 6; i++ {
+				w.Write(Message(strconv.Itoa(i)))
+			}
+			if got := w.Dropped(); got != 3 {
+				t.Errorf("Dropped() = %d, want 3", got)
+			}
+
+			close(out.gate)
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+			got := strings.Join(strings.Fields(strings.ReplaceAll(out.String(), "message=", "")), " ")
+			if got != tt.want {
+				t.Errorf("written %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAsyncWriterBlock(t *testing.T) {
+	out := newGatedWriter()
+	w := NewAsyncWriter(TextWriter(out), 1, OverflowBlock)
+	w.Write(Message("0"))
+	<-out.entered
+	w.Write(Message("1"))
+
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		w.Write(Message("2"))
+	}()
+	select {
+	case <-written:
+		t.Fatal("Write() doesn't block when the queue is full")
+	default:
+	}
+
+	close(out.gate)
+	<-written
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got := out.String(); got != "message=0\nmessage=1\nmessage=2\n" {
+		t.Errorf("written %q, want all entries", got)
+	}
+	if got := w.Dropped(); got != 0 {
+		t.Errorf("Dropped() = %d, want 0", got)
+	}
+}
+
+func TestAsyncWriterLifecycle(t *testing.T) {
+	out := &lockedBuffer{}
+	w := NewAsyncWriter(TextWriter(out), 10, OverflowBlock)
+	w.Write(Message("0"))
+	w.Flush()
+	if got := out.String(); got != "message=0\n" {
+		t.Errorf("written %q after Flush, want the queued entry", got)
+	}
+
+	w.Write(Message("1"))
+	if err := w.Sync(); err != nil {
+		t.Errorf("Sync() error = %v", err)
+	}
+	if got := out.String(); got != "message=0\nmessage=1\n" {
+		t.Errorf("written %q after Sync, want the queued entry", got)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+	w.Write(Message("2"))
+	w.Flush()
+	if got := w.Dropped(); got != 1 {
+		t.Errorf("Dropped() = %d, want the entry written after Close", got)
+	}
+}
@@ -0,0 +1,102 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"sync/atomic"
+)
+
+// AtomicLevel represents a logging priority level that can be safely changed
+// at runtime. It can be shared between loggers, so all of them are affected.
+type AtomicLevel struct {
+	v uint32
+}
+
+// NewAtomicLevel creates a new atomic level with the given initial value.
+func NewAtomicLevel(lvl Level) *AtomicLevel {
+	return &AtomicLevel{v: uint32(lvl)}
+}
+
+// Level returns the current logging priority level.
+func (l *AtomicLevel) Level() Level {
+	return Level(atomic.LoadUint32(&l.v))
+}
+
+// SetLevel changes the current logging priority level.
+func (l *AtomicLevel) SetLevel(lvl Level) {
+	atomic.StoreUint32(&l.v, uint32(lvl))
+}
+
+// Hook intercepts the logging entry and passes it to the writer if its level
+// is enabled by the current one.
+func (l *AtomicLevel) Hook(w Writer, ff ...Field) {
+	l.Level().Hook(w, ff...)
+}
+
+// MarshalText marshals the current logging priority level into text form.
+func (l *AtomicLevel) MarshalText() ([]byte, error) {
+	return l.Level().MarshalText()
+}
+
+// UnmarshalText unmarshals a text form of the logging priority level and
+// changes the current one.
+func (l *AtomicLevel) UnmarshalText(text []byte) error {
+	var lvl Level
+	if err := lvl.UnmarshalText(text); err != nil {
+		return err
+	}
+	l.SetLevel(lvl)
+	return nil
+}
+
+type levelPayload struct {
+	Level *AtomicLevel `json:"level"`
+}
+
+// ServeHTTP serves the current logging priority level. The GET method returns
+// the level and the PUT method changes it. Both methods use plain text, unless
+// the request accepts or contains json, e.g. {"level":"debug"}.
+func (l *AtomicLevel) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		l.serveLevel(w, isJSON(r.Header.Get("Accept")))
+	case http.MethodPut:
+		asJSON := isJSON(r.Header.Get("Content-Type"))
+		var err error
+		if asJSON {
+			err = json.NewDecoder(r.Body).Decode(&levelPayload{Level: l})
+		} else {
+			var text []byte
+			if text, err = io.ReadAll(r.Body); err == nil {
+				err = l.UnmarshalText(bytes.TrimSpace(text))
+			}
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		l.serveLevel(w, asJSON)
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+func (l *AtomicLevel) serveLevel(w http.ResponseWriter, asJSON bool) {
+	if asJSON {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(levelPayload{Level: l})
+		return
+	}
+	text, _ := l.MarshalText()
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write(append(text, '\n'))
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return mediaType == "application/json"
+}
@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAtomicLevel(t *testing.T) {
+	out := &bytes.Buffer{}
+	lvl := NewAtomicLevel(LevelInfo)
+	log := NewLogger().With().Writer(JSONWriter(out)).Leveler(lvl).Logger()
+	derived := log.With().String("a", "1").Logger()
+
+	derived.Debug().Message("x")
+	assertOutput(t, out, "")
+
+	lvl.SetLevel(LevelDebug)
+	if !log.Enabled(LevelDebug) {
+		t.Error("Enabled() = false after the level change")
+	}
+	derived.Debug().Message("x")
+	assertOutput(t, out, `{"level":"debug","a":"1","message":"x"}`+"\n")
+
+	if err := lvl.UnmarshalText([]byte("error")); err != nil {
+		t.Fatalf("UnmarshalText() error = %v", err)
+	}
+	if text, err := lvl.MarshalText(); err != nil || string(text) != "error" {
+		t.Errorf("MarshalText() = %s, %v, want error", text, err)
+	}
+	derived.Warn().Message("x")
+	assertOutput(t, out, "")
+}
+
+func TestAtomicLevelServeHTTP(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		accept      string
+		body        string
+		status      int
+		want        string
+		level       Level
+	}{
+		{name: "get", method: http.MethodGet, status: http.StatusOK, want: "info\n", level: LevelInfo},
+		{
+			name: "get json", method: http.MethodGet, accept: "application/json",
+			status: http.StatusOK, want: `{"level":"info"}` + "\n", level: LevelInfo,
+		},
+		{name: "put", method: http.MethodPut, body: "debug\n", status: http.StatusOK, want: "debug\n", level: LevelDebug},
+		{
+			name: "put json", method: http.MethodPut, contentType: "application/json; charset=utf-8",
+			body: `{"level":"warn"}`, status: http.StatusOK, want: `{"level":"warn"}` + "\n", level: LevelWarn,
+		},
+		{name: "put invalid", method: http.MethodPut, body: "verbose", status: http.StatusBadRequest, level: LevelInfo},
+		{
+			name: "put invalid json", method: http.MethodPut, contentType: "application/json",
+			body: `{"level":1}`, status: http.StatusBadRequest, level: LevelInfo,
+		},
+		{name: "post", method: http.MethodPost, body: "debug", status: http.StatusMethodNotAllowed, level: LevelInfo},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lvl := NewAtomicLevel(LevelInfo)
+			req := httptest.NewRequest(tt.method, "/level", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			req.Header.Set("Accept", tt.accept)
+			rec := httptest.NewRecorder()
+			lvl.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if tt.want != "" && rec.Body.String() != tt.want {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.want)
+			}
+			if tt.status == http.StatusMethodNotAllowed && rec.Header().Get("Allow") != "GET, PUT" {
+				t.Errorf("Allow = %q, want GET, PUT", rec.Header().Get("Allow"))
+			}
+			if got := lvl.Level(); got != tt.level {
+				t.Errorf("Level() = %v, want %v", got, tt.level)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"io"
+	"os"
+)
+
+// RedirectOutput redirects os.Stdout and os.Stderr through pipes into the
+// logger, so each line written to them is logged at the info and error level
+// respectively. It returns a function that restores the original streams and
+// logs the remaining output. The logger writer must not output to the
+// redirected streams, so it should be created before the call. Output written
+// directly to the file descriptors, such as runtime crash reports, is not
+// redirected.
+func RedirectOutput(log Logger) (func() error, error) {
+	stdout, err := redirect(&os.Stdout, log, "stdout", LevelInfo)
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := redirect(&os.Stderr, log, "stderr", LevelError)
+	if err != nil {
+		stdout.restore()
+		return nil, err
+	}
+	return func() error {
+		err := stdout.restore()
+		if serr := stderr.restore(); err == nil {
+			err = serr
+		}
+		return err
+	}, nil
+}
+
+type redirection struct {
+	f    **os.File
+	orig *os.File
+	r    *os.File
+	w    *os.File
+	done chan struct{}
+}
+
+func redirect(f **os.File, log Logger, stream string, lvl Level) (*redirection, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	rd := &redirection{f: f, orig: *f, r: r, w: w, done: make(chan struct{})}
+	lw := NewLineWriter(log.With().String(FieldStream, stream).Logger(), lvl, false)
+	go func() {
+		defer close(rd.done)
+		io.Copy(lw, r)
+		lw.Sync()
+	}()
+	*f = w
+	return rd, nil
+}
+
+func (rd *redirection) restore() error {
+	*rd.f = rd.orig
+	err := rd.w.Close()
+	<-rd.done
+	if rerr := rd.r.Close(); err == nil {
+		err = rerr
+	}
+	return err
+}
+
+// RecoverAndLog recovers from a panic and logs it at the error level with the
+// panic value and the stack trace. If repanic is set, the logger is synced and
+// the panic is raised again, otherwise it is swallowed. It must be called
+// directly by the defer statement, e.g. defer logger.RecoverAndLog(log, true).
+func RecoverAndLog(log Logger, repanic bool) {
+	v := recover()
+	if v == nil {
+		return
+	}
+	// The stack trace is captured here, so the one attached automatically
+	// at the error level is disabled to avoid duplicates.
+	log.stk = LevelNone
+	log.Error().
+		Any(FieldPanic, v).
+		Stack(1, stackDepth).
+		Message("panic recovered")
+	if repanic {
+		log.Sync()
+		panic(v)
+	}
+}
@@ -0,0 +1,93 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRedirectOutput(t *testing.T) {
+	out := &lockedBuffer{}
+	log := NewLogger().With().Writer(JSONWriter(out)).Logger()
+	stdout, stderr := os.Stdout, os.Stderr
+	restore, err := RedirectOutput(log)
+	if err != nil {
+		t.Fatalf("RedirectOutput() = %v", err)
+	}
+	fmt.Fprintln(os.Stdout, "a")
+	fmt.Fprint(os.Stderr, "b")
+	if err := restore(); err != nil {
+		t.Fatalf("restore() = %v", err)
+	}
+	if os.Stdout != stdout || os.Stderr != stderr {
+		t.Error("streams are not restored")
+	}
+
+	got := out.String()
+	for _, want := range []string{
+		`{"level":"info","stream":"stdout","message":"a"}` + "\n",
+		`{"level":"error","stream":"stderr","message":"b"}` + "\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output = %s, want %s", got, want)
+		}
+	}
+}
+
+func TestRecoverAndLog(t *testing.T) {
+	tests := []struct {
+		name    string
+		repanic bool
+		stack   bool
+		panic   any
+		want    string
+	}{
+		{name: "no panic", want: ""},
+		{name: "swallowed", panic: "boom", want: `{"level":"error","panic":"boom","stack":[`},
+		{name: "repanicked", repanic: true, panic: "boom", want: `{"level":"error","panic":"boom","stack":[`},
+		{name: "error value", panic: fmt.Errorf("boom"), want: `{"level":"error","panic":{"message":"boom"`},
+		{
+			name: "stack level", stack: true, panic: "boom",
+			want: `{"level":"error","panic":"boom","stack":[{"function":"runtime.gopanic"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, out := newTestLogger()
+			if tt.stack {
+				log = log.With().StackLevel(LevelError).Logger()
+			}
+			var recovered any
+			func() {
+				defer func() {
+					recovered = recover()
+				}()
+				func() {
+					defer RecoverAndLog(log, tt.repanic)
+					if tt.panic != nil {
+						panic(tt.panic)
+					}
+				}()
+			}()
+
+			var want any
+			if tt.repanic {
+				want = tt.panic
+			}
+			if recovered != want {
+				t.Errorf("recovered = %v, want %v", recovered, want)
+			}
+			got := out.String()
+			if !strings.HasPrefix(got, tt.want) || (tt.want == "" && got != "") {
+				t.Errorf("output = %s, want prefix %s", got, tt.want)
+			}
+			if n := strings.Count(got, `"stack"`); tt.want != "" && n != 1 {
+				t.Errorf("output = %s, want one stack trace", got)
+			}
+			if tt.want != "" && !strings.Contains(got, `"message":"panic recovered"`) {
+				t.Errorf("output = %s, want panic recovered message", got)
+			}
+		})
+	}
+}
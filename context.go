@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"context"
+	"time"
+)
+
+type contextKey struct{}
+
+// WithContext returns a copy of the given context that carries the logger.
+func WithContext(ctx context.Context, log Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, log)
+}
+
+// FromContext returns the logger carried by the given context. If there is
+// none, a disabled logger is returned.
+func FromContext(ctx context.Context) Logger {
+	log, _ := ctx.Value(contextKey{}).(Logger)
+	return log
+}
+
+// ContextExtractor is a function that extracts fields from the context.
+type ContextExtractor func(context.Context) []Field
+
+// ContextDeadline is a context extractor that extracts the time remaining until
+// the context deadline, if the context has one.
+func ContextDeadline(ctx context.Context) []Field {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return nil
+	}
+	return []Field{Duration(FieldDeadline, time.Until(deadline))}
+}
+
+// ContextValue creates a new context extractor that extracts a field with the
+// given key and the value the context carries for the given context key, such
+// as a request identifier or a tenant.
+func ContextValue(key string, ctxKey any) ContextExtractor {
+	return func(ctx context.Context) []Field {
+		v := ctx.Value(ctxKey)
+		if v == nil {
+			return nil
+		}
+		return []Field{Any(key, v)}
+	}
+}
+
+// EntryContext creates a new logging entry at the given level and appends
+// fields extracted from the given context by the logger context extractors.
+func (log Logger) EntryContext(ctx context.Context, lvl Level) Entry {
+	entry := log.entry(lvl, 1)
+	if !entry.Enabled() {
+		return entry
+	}
+	for _, extract := range log.ee {
+		entry = entry.With(extract(ctx)...)
+	}
+	return entry
+}
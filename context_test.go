@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testContextKey struct{}
+
+func TestFromContext(t *testing.T) {
+	if log := FromContext(context.Background()); log.Info().Enabled() {
+		t.Error("logger of empty context is enabled")
+	}
+	FromContext(context.Background()).Error(nil).Message("x")
+
+	log, out := newTestLogger()
+	ctx := WithContext(context.Background(), log.With().String("a", "1").Logger())
+	FromContext(ctx).Info().Message("x")
+	assertOutput(t, out, `{"level":"info","a":"1","message":"x"}`+"\n")
+}
+
+func TestEntryContext(t *testing.T) {
+	deadline, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{name: "no values", ctx: context.Background(), want: `{"level":"info","message":"x"}`},
+		{
+			name: "value",
+			ctx:  context.WithValue(context.Background(), testContextKey{}, "t1"),
+			want: `{"level":"info","tenant":"t1","message":"x"}`,
+		},
+		{name: "deadline", ctx: deadline, want: `{"level":"info","deadline":3`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, out := newTestLogger()
+			log = log.With().Extractors(ContextValue("tenant", testContextKey{}), ContextDeadline).Logger()
+			log.EntryContext(tt.ctx, LevelInfo).Message("x")
+			if got := out.String(); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("output = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEntryContextDisabled(t *testing.T) {
+	log, out := newTestLogger()
+	called := false
+	log = log.With().Extractors(func(context.Context) []Field {
+		called = true
+		return nil
+	}).Logger()
+	log.EntryContext(context.Background(), LevelDebug).Message("x")
+	if called {
+		t.Error("extractor is called for disabled entry")
+	}
+	assertOutput(t, out, "")
+}
@@ -4,6 +4,10 @@ import "time"
 
 // Encoder is a generic interface of the logging encoder.
 type Encoder interface {
+	// EncodeArray encodes a field with the given key and array value. Elements
+	// of the array are encoded by the given function using the same encoder,
+	// their keys are ignored.
+	EncodeArray(key string, fn func())
 	// EncodeBool encodes a field with the given key and boolean value.
 	EncodeBool(key string, b bool)
 	// EncodeBytes encodes a field with the given key and bytes value.
@@ -22,6 +26,9 @@ type Encoder interface {
 	EncodeInt32(key string, i int32)
 	// EncodeInt64 encodes a field with the given key and int64 value.
 	EncodeInt64(key string, i int64)
+	// EncodeObject encodes a field with the given key and object value. Fields
+	// of the object are encoded by the given function using the same encoder.
+	EncodeObject(key string, fn func())
 	// EncodeString encodes a field with the given key and string value.
 	EncodeString(key, s string)
 	// EncodeTime encodes a field with the given key and time value.
@@ -33,3 +40,36 @@ type Encoder interface {
 	// EncodeUint64 encodes a field with the given key and uint64 value.
 	EncodeUint64(key string, i uint64)
 }
+
+// ArrayMarshaler is a generic interface of the value that can be encoded as
+// an array.
+type ArrayMarshaler interface {
+	// MarshalLogArray encodes elements of the array with the given encoder.
+	// Keys of the elements are ignored, so they should be left empty.
+	MarshalLogArray(Encoder)
+}
+
+// ArrayFunc is an adapter to allow the use of ordinary functions as array
+// marshalers.
+type ArrayFunc func(Encoder)
+
+// MarshalLogArray encodes elements of the array with the given encoder.
+func (f ArrayFunc) MarshalLogArray(enc Encoder) {
+	f(enc)
+}
+
+// ObjectMarshaler is a generic interface of the value that can be encoded as
+// an object.
+type ObjectMarshaler interface {
+	// MarshalLogObject encodes fields of the object with the given encoder.
+	MarshalLogObject(Encoder)
+}
+
+// ObjectFunc is an adapter to allow the use of ordinary functions as object
+// marshalers.
+type ObjectFunc func(Encoder)
+
+// MarshalLogObject encodes fields of the object with the given encoder.
+func (f ObjectFunc) MarshalLogObject(enc Encoder) {
+	f(enc)
+}
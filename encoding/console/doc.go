@@ -0,0 +1,2 @@
+// Package console contains implementation of the logging console encoder.
+package console
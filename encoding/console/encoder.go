@@ -0,0 +1,235 @@
+package console
+
+import (
+	"strings"
+	"time"
+
+	"github.com/outsidedigital/logger/buffer"
+	"github.com/outsidedigital/logger/encoding/text"
+)
+
+// Keys of the fields rendered in the leading column.
+const (
+	keyLevel   = "level"
+	keyMessage = "message"
+	keyTime    = "time"
+)
+
+// ANSI escape codes.
+const (
+	colorReset   = "\x1b[0m"
+	colorDim     = "\x1b[2m"
+	colorRed     = "\x1b[31m"
+	colorGreen   = "\x1b[32m"
+	colorYellow  = "\x1b[33m"
+	colorBlue    = "\x1b[34m"
+	colorMagenta = "\x1b[35m"
+	colorGray    = "\x1b[90m"
+)
+
+var levels = map[string]struct {
+	name  string
+	color string
+}{
+	"fatal": {"FTL", colorMagenta},
+	"panic": {"PNC", colorMagenta},
+	"error": {"ERR", colorRed},
+	"warn":  {"WRN", colorYellow},
+	"info":  {"INF", colorGreen},
+	"debug": {"DBG", colorBlue},
+	"trace": {"TRC", colorGray},
+}
+
+var pool = &buffer.Pool{}
+
+// Encoder represents a logging console encoder. It renders time, level and
+// message fields as a leading column followed by the rest of the fields in
+// text format.
+type Encoder struct {
+	buf     *buffer.Buffer
+	fields  *buffer.Buffer
+	enc     *text.Encoder
+	color   bool
+	depth   int
+	time    time.Time
+	level   string
+	message string
+	blocks  []string
+}
+
+// NewEncoder creates a new console encoder that writes to the given buffer,
+// once it is flushed. If color is set, the output contains ANSI escape codes.
+func NewEncoder(buf *buffer.Buffer, color bool) *Encoder {
+	fields := pool.Get()
+	return &Encoder{buf: buf, fields: fields, enc: text.NewEncoder(fields), color: color}
+}
+
+// EncodeArray encodes a field with the given key and array value. Elements of
+// the array are encoded by the given function using the same encoder.
+func (enc *Encoder) EncodeArray(key string, fn func()) {
+	enc.enc.EncodeArray(key, enc.nest(fn))
+}
+
+// EncodeBlock encodes a field with the given key and multi-line value.
+// Top-level multi-line fields are rendered on separate lines after other
+// fields.
+func (enc *Encoder) EncodeBlock(key, s string) {
+	if enc.depth > 0 {
+		enc.enc.EncodeString(key, s)
+		return
+	}
+	enc.blocks = append(enc.blocks, key, s)
+}
+
+// EncodeBool encodes a field with the given key and boolean value.
+func (enc *Encoder) EncodeBool(key string, b bool) {
+	enc.enc.EncodeBool(key, b)
+}
+
+// EncodeBytes encodes a field with the given key and bytes value.
+func (enc *Encoder) EncodeBytes(key string, p []byte) {
+	enc.enc.EncodeBytes(key, p)
+}
+
+// EncodeDuration encodes a field with the given key and duration value in
+// a human-friendly form.
+func (enc *Encoder) EncodeDuration(key string, d time.Duration) {
+	enc.enc.EncodeString(key, d.String())
+}
+
+// EncodeError encodes a field with the given key and error value.
+func (enc *Encoder) EncodeError(key string, err error) {
+	enc.enc.EncodeError(key, err)
+}
+
+// EncodeFloat32 encodes a field with the given key and float32 value.
+func (enc *Encoder) EncodeFloat32(key string, f float32) {
+	enc.enc.EncodeFloat32(key, f)
+}
+
+// EncodeFloat64 encodes a field with the given key and float64 value.
+func (enc *Encoder) EncodeFloat64(key string, f float64) {
+	enc.enc.EncodeFloat64(key, f)
+}
+
+// EncodeInt encodes a field with the given key and integer value.
+func (enc *Encoder) EncodeInt(key string, i int) {
+	enc.enc.EncodeInt(key, i)
+}
+
+// EncodeInt32 encodes a field with the given key and int32 value.
+func (enc *Encoder) EncodeInt32(key string, i int32) {
+	enc.enc.EncodeInt32(key, i)
+}
+
+// EncodeInt64 encodes a field with the given key and int64 value.
+func (enc *Encoder) EncodeInt64(key string, i int64) {
+	enc.enc.EncodeInt64(key, i)
+}
+
+// EncodeObject encodes a field with the given key and object value. Fields of
+// the object are encoded by the given function using the same encoder.
+func (enc *Encoder) EncodeObject(key string, fn func()) {
+	enc.enc.EncodeObject(key, enc.nest(fn))
+}
+
+// EncodeString encodes a field with the given key and string value. Top-level
+// level and message fields are rendered in the leading column.
+func (enc *Encoder) EncodeString(key, s string) {
+	if enc.depth == 0 {
+		switch key {
+		case keyLevel:
+			enc.level = s
+			return
+		case keyMessage:
+			enc.message = s
+			return
+		}
+	}
+	enc.enc.EncodeString(key, s)
+}
+
+// EncodeTime encodes a field with the given key and time value. Top-level time
+// field is rendered in the leading column.
+func (enc *Encoder) EncodeTime(key string, t time.Time) {
+	if enc.depth == 0 && key == keyTime {
+		enc.time = t
+		return
+	}
+	enc.enc.EncodeTime(key, t)
+}
+
+// EncodeUint encodes a field with the given key and unsigned integer value.
+func (enc *Encoder) EncodeUint(key string, i uint) {
+	enc.enc.EncodeUint(key, i)
+}
+
+// EncodeUint32 encodes a field with the given key and uint32 value.
+func (enc *Encoder) EncodeUint32(key string, i uint32) {
+	enc.enc.EncodeUint32(key, i)
+}
+
+// EncodeUint64 encodes a field with the given key and uint64 value.
+func (enc *Encoder) EncodeUint64(key string, i uint64) {
+	enc.enc.EncodeUint64(key, i)
+}
+
+// Flush appends the leading column followed by the rest of the encoded fields
+// to the buffer. Once this method is called, the encoder should be disposed.
+func (enc *Encoder) Flush() {
+	defer pool.Put(enc.fields)
+
+	if !enc.time.IsZero() {
+		enc.appendColored(colorGray, enc.time.Format("15:04:05.000"))
+	}
+	if enc.level != "" {
+		lvl, ok := levels[enc.level]
+		if !ok {
+			lvl.name = strings.ToUpper(enc.level)
+		}
+		enc.appendSeparator()
+		enc.appendColored(lvl.color, lvl.name)
+	}
+	if enc.message != "" {
+		enc.appendSeparator()
+		text.AppendEscaped(enc.buf, enc.message)
+	}
+	if enc.fields.Len() > 0 {
+		enc.appendSeparator()
+		enc.appendColored(colorDim, string(enc.fields.Bytes()))
+	}
+	for i := 0; i < len(enc.blocks); i += 2 {
+		if enc.buf.Len() > 0 {
+			enc.buf.AppendByte('\n')
+		}
+		enc.appendColored(colorDim, enc.blocks[i]+":")
+		for _, line := range strings.Split(enc.blocks[i+1], "\n") {
+			enc.buf.AppendString("\n\t")
+			enc.appendColored(colorDim, line)
+		}
+	}
+}
+
+func (enc *Encoder) nest(fn func()) func() {
+	return func() {
+		enc.depth++
+		fn()
+		enc.depth--
+	}
+}
+
+func (enc *Encoder) appendSeparator() {
+	if enc.buf.Len() > 0 {
+		enc.buf.AppendByte(' ')
+	}
+}
+
+func (enc *Encoder) appendColored(color, s string) {
+	if !enc.color || color == "" {
+		enc.buf.AppendString(s)
+		return
+	}
+	enc.buf.AppendString(color)
+	enc.buf.AppendString(s)
+	enc.buf.AppendString(colorReset)
+}
@@ -0,0 +1,86 @@
+package console
+
+import (
+	"testing"
+	"time"
+
+	"github.com/outsidedigital/logger/buffer"
+)
+
+func TestEncoder(t *testing.T) {
+	tm := time.Date(2022, 4, 1, 12, 30, 45, 123e6, time.UTC)
+	tests := []struct {
+		name  string
+		color bool
+		enc   func(*Encoder)
+		want  string
+	}{
+		{
+			name: "leading column",
+			enc: func(enc *Encoder) {
+				enc.EncodeString(keyLevel, "info")
+				enc.EncodeTime(keyTime, tm)
+				enc.EncodeString("a", "1")
+				enc.EncodeString(keyMessage, "hello")
+			},
+			want: "12:30:45.123 INF hello a=1",
+		},
+		{
+			name:  "color",
+			color: true,
+			enc: func(enc *Encoder) {
+				enc.EncodeString(keyLevel, "error")
+				enc.EncodeString(keyMessage, "hello")
+				enc.EncodeInt("a", 1)
+			},
+			want: colorRed + "ERR" + colorReset + " hello " + colorDim + "a=1" + colorReset,
+		},
+		{
+			name: "unknown level",
+			enc: func(enc *Encoder) {
+				enc.EncodeString(keyLevel, "notice")
+			},
+			want: "NOTICE",
+		},
+		{
+			name: "escaped message",
+			enc: func(enc *Encoder) {
+				enc.EncodeString(keyMessage, "a\nb\x1b[31mc\x00")
+			},
+			want: `a\nb\u001b[31mc\u0000`,
+		},
+		{
+			name: "nested fields",
+			enc: func(enc *Encoder) {
+				enc.EncodeObject("o", func() {
+					enc.EncodeString(keyMessage, "nested")
+					enc.EncodeDuration("d", time.Second)
+				})
+				enc.EncodeString(keyMessage, "top")
+			},
+			want: "top o.message=nested o.d=1s",
+		},
+		{
+			name: "block",
+			enc: func(enc *Encoder) {
+				enc.EncodeString(keyMessage, "x")
+				enc.EncodeBlock("stack", "a\n\tb")
+				enc.EncodeArray("arr", func() {
+					enc.EncodeBlock("", "c\nd")
+				})
+			},
+			want: "x arr=[c\\nd]\nstack:\n\ta\n\t\tb",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &buffer.Buffer{}
+			enc := NewEncoder(buf, tt.color)
+			tt.enc(enc)
+			enc.Flush()
+			if got := buf.String(); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -10,11 +10,21 @@ import (
 type Encoder struct {
 	buf *buffer.Buffer
 	n   int
+	arr bool
 }
 
 // NewEncoder creates a new json encoder that writes to the given buffer.
 func NewEncoder(buf *buffer.Buffer) *Encoder {
-	return &Encoder{buf: buf, n: 0}
+	return &Encoder{buf: buf, n: 0, arr: false}
+}
+
+// EncodeArray encodes a field with the given key and array value. Elements of
+// the array are encoded by the given function using the same encoder.
+func (enc *Encoder) EncodeArray(key string, fn func()) {
+	enc.appendKey(key)
+	enc.buf.AppendByte('[')
+	enc.nest(true, fn)
+	enc.buf.AppendByte(']')
 }
 
 // EncodeBool encodes a field with the given key and boolean value.
@@ -84,6 +94,15 @@ func (enc *Encoder) EncodeInt64(key string, i int64) {
 	enc.buf.AppendInt(i, 10)
 }
 
+// EncodeObject encodes a field with the given key and object value. Fields of
+// the object are encoded by the given function using the same encoder.
+func (enc *Encoder) EncodeObject(key string, fn func()) {
+	enc.appendKey(key)
+	enc.buf.AppendByte('{')
+	enc.nest(false, fn)
+	enc.buf.AppendByte('}')
+}
+
 // EncodeString encodes a field with the given key and string value.
 func (enc *Encoder) EncodeString(key, s string) {
 	enc.appendKey(key)
@@ -120,11 +139,21 @@ func (enc *Encoder) appendKey(key string) {
 	if enc.n > 0 {
 		enc.buf.AppendByte(',')
 	}
+	enc.n++
+	if enc.arr {
+		return
+	}
 	enc.buf.AppendQuote(key)
 	enc.buf.AppendByte(':')
-	enc.n++
 }
 
 func (enc *Encoder) appendNull() {
 	enc.buf.AppendString("null")
 }
+
+func (enc *Encoder) nest(arr bool, fn func()) {
+	n, a := enc.n, enc.arr
+	enc.n, enc.arr = 0, arr
+	fn()
+	enc.n, enc.arr = n, a
+}
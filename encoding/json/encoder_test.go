@@ -0,0 +1,96 @@
+package json
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/outsidedigital/logger/buffer"
+)
+
+func TestEncoder(t *testing.T) {
+	tests := []struct {
+		name string
+		enc  func(*Encoder)
+		want string
+	}{
+		{
+			name: "scalars",
+			enc: func(enc *Encoder) {
+				enc.EncodeBool("b", true)
+				enc.EncodeInt("i", -1)
+				enc.EncodeUint64("u", 2)
+				enc.EncodeFloat64("f", 1.5)
+				enc.EncodeString("s", "a\"b")
+				enc.EncodeTime("t", time.Date(2022, 4, 1, 12, 0, 0, 0, time.UTC))
+			},
+			want: `"b":true,"i":-1,"u":2,"f":1.5,"s":"a\"b","t":"2022-04-01T12:00:00Z"`,
+		},
+		{
+			name: "errors",
+			enc: func(enc *Encoder) {
+				enc.EncodeError("a", errors.New("failed"))
+				enc.EncodeError("b", nil)
+			},
+			want: `"a":"failed","b":null`,
+		},
+		{
+			name: "object",
+			enc: func(enc *Encoder) {
+				enc.EncodeString("a", "1")
+				enc.EncodeObject("o", func() {
+					enc.EncodeString("b", "2")
+					enc.EncodeInt("c", 3)
+				})
+				enc.EncodeString("d", "4")
+			},
+			want: `"a":"1","o":{"b":"2","c":3},"d":"4"`,
+		},
+		{
+			name: "empty object",
+			enc: func(enc *Encoder) {
+				enc.EncodeObject("o", func() {})
+			},
+			want: `"o":{}`,
+		},
+		{
+			name: "array",
+			enc: func(enc *Encoder) {
+				enc.EncodeArray("a", func() {
+					enc.EncodeInt("ignored", 1)
+					enc.EncodeString("", "2")
+				})
+				enc.EncodeArray("e", func() {})
+			},
+			want: `"a":[1,"2"],"e":[]`,
+		},
+		{
+			name: "nested",
+			enc: func(enc *Encoder) {
+				enc.EncodeArray("a", func() {
+					enc.EncodeObject("", func() {
+						enc.EncodeInt("x", 1)
+						enc.EncodeArray("y", func() {
+							enc.EncodeInt("", 2)
+							enc.EncodeInt("", 3)
+						})
+					})
+					enc.EncodeArray("", func() {
+						enc.EncodeBool("", false)
+					})
+				})
+				enc.EncodeInt("z", 4)
+			},
+			want: `"a":[{"x":1,"y":[2,3]},[false]],"z":4`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &buffer.Buffer{}
+			tt.enc(NewEncoder(buf))
+			if got := buf.String(); got != tt.want {
+				t.Errorf("output = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
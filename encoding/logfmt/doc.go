@@ -0,0 +1,2 @@
+// Package logfmt contains implementation of the logging logfmt encoder.
+package logfmt
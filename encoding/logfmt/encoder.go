@@ -0,0 +1,266 @@
+package logfmt
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/outsidedigital/logger/buffer"
+)
+
+// null is a value of nil fields.
+const null = "null"
+
+const hex = "0123456789abcdef"
+
+var pool = &buffer.Pool{}
+
+// Encoder represents a logging logfmt encoder. Values that contain spaces,
+// equal signs, quotes or control characters are quoted and escaped, while keys
+// have such characters replaced with underscores.
+type Encoder struct {
+	buf    *buffer.Buffer
+	prefix string
+	n      int
+	arr    bool
+	nested bool
+}
+
+// NewEncoder creates a new logfmt encoder that writes to the given buffer.
+func NewEncoder(buf *buffer.Buffer) *Encoder {
+	return &Encoder{buf: buf}
+}
+
+// EncodeArray encodes a field with the given key and array value. Elements of
+// the array are encoded by the given function using the same encoder and are
+// enclosed in brackets, which are quoted as a single value.
+func (enc *Encoder) EncodeArray(key string, fn func()) {
+	enc.appendKey(key)
+	if enc.nested {
+		enc.buf.AppendByte('[')
+		enc.nest(true, fn)
+		enc.buf.AppendByte(']')
+		return
+	}
+
+	buf := pool.Get()
+	defer pool.Put(buf)
+
+	out := enc.buf
+	enc.buf = buf
+	buf.AppendByte('[')
+	enc.nest(true, fn)
+	buf.AppendByte(']')
+	enc.buf = out
+	enc.appendQuote(string(buf.Bytes()))
+}
+
+// EncodeBool encodes a field with the given key and boolean value.
+func (enc *Encoder) EncodeBool(key string, b bool) {
+	enc.appendKey(key)
+	enc.buf.AppendBool(b)
+}
+
+// EncodeBytes encodes a field with the given key and bytes value in the
+// hexadecimal form.
+func (enc *Encoder) EncodeBytes(key string, p []byte) {
+	enc.appendKey(key)
+	if len(p) == 0 {
+		enc.buf.AppendString(`""`)
+		return
+	}
+	for _, c := range p {
+		enc.buf.AppendByte(hex[c>>4])
+		enc.buf.AppendByte(hex[c&0xf])
+	}
+}
+
+// EncodeDuration encodes a field with the given key and duration value.
+func (enc *Encoder) EncodeDuration(key string, d time.Duration) {
+	enc.appendKey(key)
+	enc.buf.AppendDuration(d)
+	enc.buf.AppendByte('s')
+}
+
+// EncodeError encodes a field with the given key and error value. Nil errors
+// are encoded as null.
+func (enc *Encoder) EncodeError(key string, err error) {
+	enc.appendKey(key)
+	if err == nil {
+		enc.buf.AppendString(null)
+		return
+	}
+	enc.appendValue(err.Error())
+}
+
+// EncodeFloat32 encodes a field with the given key and float32 value.
+func (enc *Encoder) EncodeFloat32(key string, f float32) {
+	enc.appendKey(key)
+	enc.buf.AppendFloat(float64(f), 32)
+}
+
+// EncodeFloat64 encodes a field with the given key and float64 value.
+func (enc *Encoder) EncodeFloat64(key string, f float64) {
+	enc.appendKey(key)
+	enc.buf.AppendFloat(f, 64)
+}
+
+// EncodeInt encodes a field with the given key and integer value.
+func (enc *Encoder) EncodeInt(key string, i int) {
+	enc.appendKey(key)
+	enc.buf.AppendInt(int64(i), 10)
+}
+
+// EncodeInt32 encodes a field with the given key and int32 value.
+func (enc *Encoder) EncodeInt32(key string, i int32) {
+	enc.appendKey(key)
+	enc.buf.AppendInt(int64(i), 10)
+}
+
+// EncodeInt64 encodes a field with the given key and int64 value.
+func (enc *Encoder) EncodeInt64(key string, i int64) {
+	enc.appendKey(key)
+	enc.buf.AppendInt(i, 10)
+}
+
+// EncodeObject encodes a field with the given key and object value. Fields of
+// the object are encoded by the given function using the same encoder and
+// their keys are prefixed with the object key. Objects that are elements of
+// an array are enclosed in braces instead.
+func (enc *Encoder) EncodeObject(key string, fn func()) {
+	if enc.arr {
+		enc.appendSeparator()
+		enc.buf.AppendByte('{')
+		enc.nest(false, fn)
+		enc.buf.AppendByte('}')
+		return
+	}
+	prefix := enc.prefix
+	enc.prefix = prefix + key + "."
+	fn()
+	enc.prefix = prefix
+}
+
+// EncodeString encodes a field with the given key and string value. Empty
+// strings are encoded as a pair of quotes.
+func (enc *Encoder) EncodeString(key, s string) {
+	enc.appendKey(key)
+	enc.appendValue(s)
+}
+
+// EncodeTime encodes a field with the given key and time value.
+func (enc *Encoder) EncodeTime(key string, t time.Time) {
+	enc.appendKey(key)
+	enc.buf.AppendTime(t, time.RFC3339)
+}
+
+// EncodeUint encodes a field with the given key and unsigned integer value.
+func (enc *Encoder) EncodeUint(key string, i uint) {
+	enc.appendKey(key)
+	enc.buf.AppendUint(uint64(i), 10)
+}
+
+// EncodeUint32 encodes a field with the given key and uint32 value.
+func (enc *Encoder) EncodeUint32(key string, i uint32) {
+	enc.appendKey(key)
+	enc.buf.AppendUint(uint64(i), 10)
+}
+
+// EncodeUint64 encodes a field with the given key and uint64 value.
+func (enc *Encoder) EncodeUint64(key string, i uint64) {
+	enc.appendKey(key)
+	enc.buf.AppendUint(i, 10)
+}
+
+func (enc *Encoder) appendKey(key string) {
+	enc.appendSeparator()
+	if enc.arr {
+		return
+	}
+	if enc.prefix == "" && key == "" {
+		enc.buf.AppendByte('_')
+	}
+	enc.appendName(enc.prefix)
+	enc.appendName(key)
+	enc.buf.AppendByte('=')
+}
+
+func (enc *Encoder) appendSeparator() {
+	if enc.nested {
+		if enc.n > 0 {
+			enc.buf.AppendByte(' ')
+		}
+		enc.n++
+		return
+	}
+	if enc.buf.Len() > 0 {
+		enc.buf.AppendByte(' ')
+	}
+}
+
+// appendName appends the given key replacing characters that are not allowed
+// in keys with underscores.
+func (enc *Encoder) appendName(s string) {
+	for _, c := range s {
+		if c <= ' ' || c == '=' || c == '"' || c == utf8.RuneError || c == '\u007f' {
+			enc.buf.AppendByte('_')
+			continue
+		}
+		enc.buf.AppendRune(c)
+	}
+}
+
+// appendValue appends the given value, quoting it if necessary.
+func (enc *Encoder) appendValue(s string) {
+	if needsQuote(s) {
+		enc.appendQuote(s)
+		return
+	}
+	enc.buf.AppendString(s)
+}
+
+func (enc *Encoder) appendQuote(s string) {
+	enc.buf.AppendByte('"')
+	for _, c := range s {
+		switch c {
+		case '"', '\\':
+			enc.buf.AppendByte('\\')
+			enc.buf.AppendRune(c)
+		case '\n':
+			enc.buf.AppendString(`\n`)
+		case '\r':
+			enc.buf.AppendString(`\r`)
+		case '\t':
+			enc.buf.AppendString(`\t`)
+		default:
+			if c < ' ' || c == '\u007f' {
+				enc.buf.AppendString(`\u00`)
+				enc.buf.AppendByte(hex[c>>4])
+				enc.buf.AppendByte(hex[c&0xf])
+				continue
+			}
+			enc.buf.AppendRune(c)
+		}
+	}
+	enc.buf.AppendByte('"')
+}
+
+func (enc *Encoder) nest(arr bool, fn func()) {
+	prefix, n, a, nested := enc.prefix, enc.n, enc.arr, enc.nested
+	enc.prefix, enc.n, enc.arr, enc.nested = "", 0, arr, true
+	fn()
+	enc.prefix, enc.n, enc.arr, enc.nested = prefix, n, a, nested
+}
+
+// needsQuote checks whether the value can't be represented as a bare word.
+// Empty values and the null word are quoted to distinguish them from nil ones.
+func needsQuote(s string) bool {
+	if s == "" || s == null {
+		return true
+	}
+	for _, c := range s {
+		if c <= ' ' || c == '=' || c == '"' || c == '\\' || c == utf8.RuneError || c == '\u007f' {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package logfmt
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/outsidedigital/logger/buffer"
+)
+
+func TestEncoder(t *testing.T) {
+	tests := []struct {
+		name string
+		enc  func(*Encoder)
+		want string
+	}{
+		{
+			name: "scalars",
+			enc: func(enc *Encoder) {
+				enc.EncodeBool("b", true)
+				enc.EncodeInt("i", -1)
+				enc.EncodeFloat64("f", 1.5)
+				enc.EncodeDuration("d", 1500*time.Millisecond)
+				enc.EncodeTime("t", time.Date(2022, 4, 1, 12, 0, 0, 0, time.UTC))
+			},
+			want: `b=true i=-1 f=1.5 d=1.5s t=2022-04-01T12:00:00Z`,
+		},
+		{
+			name: "bare strings",
+			enc: func(enc *Encoder) {
+				enc.EncodeString("a", "word")
+				enc.EncodeString("b", "zażółć")
+			},
+			want: `a=word b=zażółć`,
+		},
+		{
+			name: "quoted strings",
+			enc: func(enc *Encoder) {
+				enc.EncodeString("empty", "")
+				enc.EncodeString("null", "null")
+				enc.EncodeString("space", "a b")
+				enc.EncodeString("equal", "a=b")
+				enc.EncodeString("quote", `a"b`)
+				enc.EncodeString("backslash", `a\b`)
+				enc.EncodeString("control", "a\nb\t\x1b")
+			},
+			want: `empty="" null="null" space="a b" equal="a=b" quote="a\"b" backslash="a\\b" ` +
+				`control="a\nb\t\u001b"`,
+		},
+		{
+			name: "keys",
+			enc: func(enc *Encoder) {
+				enc.EncodeInt("a b=c\"d", 1)
+				enc.EncodeInt("", 2)
+			},
+			want: `a_b_c_d=1 _=2`,
+		},
+		{
+			name: "errors",
+			enc: func(enc *Encoder) {
+				enc.EncodeError("a", errors.New("failed badly"))
+				enc.EncodeError("b", nil)
+			},
+			want: `a="failed badly" b=null`,
+		},
+		{
+			name: "bytes",
+			enc: func(enc *Encoder) {
+				enc.EncodeBytes("a", []byte{0x01, 0xab})
+				enc.EncodeBytes("b", nil)
+			},
+			want: `a=01ab b=""`,
+		},
+		{
+			name: "object",
+			enc: func(enc *Encoder) {
+				enc.EncodeObject("o", func() {
+					enc.EncodeString("a", "1")
+					enc.EncodeObject("p", func() {
+						enc.EncodeString("b", "x y")
+					})
+				})
+			},
+			want: `o.a=1 o.p.b="x y"`,
+		},
+		{
+			name: "array",
+			enc: func(enc *Encoder) {
+				enc.EncodeArray("a", func() {
+					enc.EncodeInt("", 1)
+					enc.EncodeObject("", func() {
+						enc.EncodeString("b", "x y")
+					})
+				})
+				enc.EncodeInt("c", 2)
+			},
+			want: `a="[1 {b=\"x y\"}]" c=2`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &buffer.Buffer{}
+			tt.enc(NewEncoder(buf))
+			if got := buf.String(); got != tt.want {
+				t.Errorf("output = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
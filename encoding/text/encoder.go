@@ -1,14 +1,28 @@
 package text
 
 import (
+	"strings"
 	"time"
 
 	"github.com/outsidedigital/logger/buffer"
 )
 
+const hex = "0123456789abcdef"
+
 // Encoder represents a logging text encoder.
 type Encoder struct {
-	buf *buffer.Buffer
+	buf    *buffer.Buffer
+	prefix string
+	n      int
+	arr    bool
+	nested bool
+	blocks []block
+}
+
+// block represents a multi-line field, that is appended after other fields.
+type block struct {
+	key string
+	s   string
 }
 
 // NewEncoder creates a new json encoder that writes to the given buffer.
@@ -16,6 +30,28 @@ func NewEncoder(buf *buffer.Buffer) *Encoder {
 	return &Encoder{buf: buf}
 }
 
+// EncodeArray encodes a field with the given key and array value. Elements of
+// the array are encoded by the given function using the same encoder and are
+// enclosed in brackets.
+func (enc *Encoder) EncodeArray(key string, fn func()) {
+	enc.appendKey(key)
+	enc.buf.AppendByte('[')
+	enc.nest(true, fn)
+	enc.buf.AppendByte(']')
+}
+
+// EncodeBlock encodes a field with the given key and multi-line value. The
+// field is appended on separate lines after other fields once the encoder is
+// flushed, with each line of the value indented. Nested fields are encoded as
+// plain strings instead.
+func (enc *Encoder) EncodeBlock(key, s string) {
+	if enc.nested {
+		enc.EncodeString(key, s)
+		return
+	}
+	enc.blocks = append(enc.blocks, block{key: enc.prefix + key, s: s})
+}
+
 // EncodeBool encodes a field with the given key and boolean value.
 func (enc *Encoder) EncodeBool(key string, b bool) {
 	enc.appendKey(key)
@@ -80,6 +116,24 @@ func (enc *Encoder) EncodeInt64(key string, i int64) {
 	enc.buf.AppendInt(i, 10)
 }
 
+// EncodeObject encodes a field with the given key and object value. Fields of
+// the object are encoded by the given function using the same encoder and
+// their keys are prefixed with the object key. Objects that are elements of
+// an array are enclosed in braces instead.
+func (enc *Encoder) EncodeObject(key string, fn func()) {
+	if enc.arr {
+		enc.appendSeparator()
+		enc.buf.AppendByte('{')
+		enc.nest(false, fn)
+		enc.buf.AppendByte('}')
+		return
+	}
+	prefix := enc.prefix
+	enc.prefix = prefix + key + "."
+	fn()
+	enc.prefix = prefix
+}
+
 // EncodeString encodes a field with the given key and string value.
 func (enc *Encoder) EncodeString(key, s string) {
 	enc.appendKey(key)
@@ -110,33 +164,85 @@ func (enc *Encoder) EncodeUint64(key string, i uint64) {
 	enc.buf.AppendUint(i, 10)
 }
 
+// Flush appends multi-line fields to the buffer. Once this method is called,
+// the encoder should be disposed.
+func (enc *Encoder) Flush() {
+	for _, b := range enc.blocks {
+		if enc.buf.Len() > 0 {
+			enc.buf.AppendByte('\n')
+		}
+		enc.buf.AppendString(b.key)
+		enc.buf.AppendByte(':')
+		for _, line := range strings.Split(b.s, "\n") {
+			enc.buf.AppendString("\n\t")
+			enc.buf.AppendString(line)
+		}
+	}
+	enc.blocks = nil
+}
+
 func (enc *Encoder) appendKey(key string) {
-	if enc.buf.Len() > 0 {
-		enc.buf.AppendByte(' ')
+	enc.appendSeparator()
+	if enc.arr {
+		return
 	}
+	enc.appendString(enc.prefix)
 	enc.appendString(key)
 	enc.buf.AppendByte('=')
 }
 
+func (enc *Encoder) appendSeparator() {
+	if enc.nested {
+		if enc.n > 0 {
+			enc.buf.AppendByte(' ')
+		}
+		enc.n++
+		return
+	}
+	if enc.buf.Len() > 0 {
+		enc.buf.AppendByte(' ')
+	}
+}
+
 func (enc *Encoder) appendString(s string) {
+	AppendEscaped(enc.buf, s)
+}
+
+// AppendEscaped appends the given string to the buffer, escaping control
+// characters, so the string can't break the line or inject terminal escape
+// sequences.
+func AppendEscaped(buf *buffer.Buffer, s string) {
 	for _, c := range s {
 		switch c {
 		case '\a':
-			enc.buf.AppendString(`\a`)
+			buf.AppendString(`\a`)
 		case '\b':
-			enc.buf.AppendString(`\b`)
+			buf.AppendString(`\b`)
 		case '\f':
-			enc.buf.AppendString(`\f`)
+			buf.AppendString(`\f`)
 		case '\n':
-			enc.buf.AppendString(`\n`)
+			buf.AppendString(`\n`)
 		case '\r':
-			enc.buf.AppendString(`\r`)
+			buf.AppendString(`\r`)
 		case '\t':
-			enc.buf.AppendString(`\t`)
+			buf.AppendString(`\t`)
 		case '\v':
-			enc.buf.AppendString(`\v`)
+			buf.AppendString(`\v`)
 		default:
-			enc.buf.AppendRune(c)
+			if c < ' ' || c == '\u007f' {
+				buf.AppendString(`\u00`)
+				buf.AppendByte(hex[c>>4])
+				buf.AppendByte(hex[c&0xf])
+				continue
+			}
+			buf.AppendRune(c)
 		}
 	}
 }
+
+func (enc *Encoder) nest(arr bool, fn func()) {
+	prefix, n, a, nested := enc.prefix, enc.n, enc.arr, enc.nested
+	enc.prefix, enc.n, enc.arr, enc.nested = "", 0, arr, true
+	fn()
+	enc.prefix, enc.n, enc.arr, enc.nested = prefix, n, a, nested
+}
@@ -0,0 +1,130 @@
+package text
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/outsidedigital/logger/buffer"
+)
+
+func TestEncoder(t *testing.T) {
+	tests := []struct {
+		name string
+		enc  func(*Encoder)
+		want string
+	}{
+		{
+			name: "scalars",
+			enc: func(enc *Encoder) {
+				enc.EncodeBool("b", true)
+				enc.EncodeInt("i", -1)
+				enc.EncodeFloat64("f", 1.5)
+				enc.EncodeString("s", "a b")
+				enc.EncodeDuration("d", 1500*time.Millisecond)
+			},
+			want: `b=true i=-1 f=1.5 s=a b d=1.5s`,
+		},
+		{
+			name: "errors",
+			enc: func(enc *Encoder) {
+				enc.EncodeError("a", errors.New("failed"))
+				enc.EncodeError("b", nil)
+			},
+			want: `a=failed`,
+		},
+		{
+			name: "object",
+			enc: func(enc *Encoder) {
+				enc.EncodeString("a", "1")
+				enc.EncodeObject("o", func() {
+					enc.EncodeString("b", "2")
+					enc.EncodeObject("p", func() {
+						enc.EncodeInt("c", 3)
+					})
+				})
+				enc.EncodeString("d", "4")
+			},
+			want: `a=1 o.b=2 o.p.c=3 d=4`,
+		},
+		{
+			name: "array",
+			enc: func(enc *Encoder) {
+				enc.EncodeArray("a", func() {
+					enc.EncodeInt("ignored", 1)
+					enc.EncodeString("", "2")
+				})
+				enc.EncodeArray("e", func() {})
+			},
+			want: `a=[1 2] e=[]`,
+		},
+		{
+			name: "nested",
+			enc: func(enc *Encoder) {
+				enc.EncodeObject("o", func() {
+					enc.EncodeArray("a", func() {
+						enc.EncodeObject("", func() {
+							enc.EncodeInt("x", 1)
+							enc.EncodeArray("y", func() {
+								enc.EncodeInt("", 2)
+								enc.EncodeInt("", 3)
+							})
+						})
+						enc.EncodeArray("", func() {
+							enc.EncodeBool("", false)
+						})
+					})
+				})
+				enc.EncodeInt("z", 4)
+			},
+			want: `o.a=[{x=1 y=[2 3]} [false]] z=4`,
+		},
+		{
+			name: "block",
+			enc: func(enc *Encoder) {
+				enc.EncodeBlock("stack", "a\n\tb")
+				enc.EncodeObject("o", func() {
+					enc.EncodeBlock("s", "c")
+				})
+				enc.EncodeString("x", "1")
+			},
+			want: "x=1\nstack:\n\ta\n\t\tb\no.s:\n\tc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &buffer.Buffer{}
+			enc := NewEncoder(buf)
+			tt.enc(enc)
+			enc.Flush()
+			if got := buf.String(); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendEscaped(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{name: "plain", s: "hello world", want: "hello world"},
+		{name: "unicode", s: "zażółć", want: "zażółć"},
+		{name: "line breaks", s: "a\nb\rc", want: `a\nb\rc`},
+		{name: "whitespace", s: "a\tb\vc\fd", want: `a\tb\vc\fd`},
+		{name: "bell and backspace", s: "\a\b", want: `\a\b`},
+		{name: "escape sequence", s: "\x1b[31mred", want: `\u001b[31mred`},
+		{name: "other control", s: "\x00\x7f", want: `\u0000\u007f`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &buffer.Buffer{}
+			AppendEscaped(buf, tt.s)
+			if got := buf.String(); got != tt.want {
+				t.Errorf("AppendEscaped() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -8,8 +8,10 @@ import (
 
 // Entry represents a structured logging entry.
 type Entry struct {
-	w  Writer
-	ff []Field
+	w    Writer
+	ff   []Field
+	ns   []namespace
+	done func(msg string)
 }
 
 var entryPool = &sync.Pool{
@@ -28,61 +30,130 @@ func NewEntry(w Writer) Entry {
 // Message appends a given message to the entry and sends it to the underlying
 // writer. Once this method is called, the entry should be disposed.
 func (e Entry) Message(msg string) {
-	e.ff = append(e.ff, Message(msg))
+	if !e.Enabled() {
+		if e.done != nil {
+			e.done(msg)
+		}
+		return
+	}
+	ff := e.ff
+	for i := len(e.ns) - 1; i >= 0; i-- {
+		n := len(e.ns[i].ff)
+		if len(ff) == 0 {
+			ff = e.ns[i].ff[:n:n]
+			continue
+		}
+		ff = append(e.ns[i].ff[:n:n], Group(e.ns[i].key, ff...))
+	}
+	e.ff = append(ff, Message(msg))
 	e.w.Write(e.ff...)
+	done := e.done
 	e.Discard()
+	if done != nil {
+		done(msg)
+	}
 }
 
 // Messagef appends a given formatted message to the entry and sends it to the
 // underlying writer. Once this method is called, the entry should be disposed.
 func (e Entry) Messagef(format string, a ...any) {
+	if !e.Enabled() && e.done == nil {
+		return
+	}
 	e.Message(fmt.Sprintf(format, a...))
 }
 
+// Enabled checks whether the entry is enabled. Disabled entries, which are
+// created for disabled levels, ignore all appended fields and are never sent
+// to the writer.
+func (e Entry) Enabled() bool {
+	return e.w != nil
+}
+
 // Discard discards the entry, so it won't be logged. Once this method is
 // called, the entry should be disposed.
 func (e Entry) Discard() {
+	if !e.Enabled() {
+		return
+	}
 	entryPool.Put(e.Reset())
 }
 
 // Reset resets previously stored fields.
 func (e Entry) Reset() Entry {
 	e.ff = e.ff[:0]
+	e.ns = nil
+	e.done = nil
+	return e
+}
+
+// Any appends a new field with the given key and arbitrary value.
+func (e Entry) Any(key string, v any) Entry {
+	if !e.Enabled() {
+		return e
+	}
+	e.ff = append(e.ff, Any(key, v))
+	return e
+}
+
+// Array appends a new field with the given key and array value.
+func (e Entry) Array(key string, v ArrayMarshaler) Entry {
+	if !e.Enabled() {
+		return e
+	}
+	e.ff = append(e.ff, Array(key, v))
 	return e
 }
 
 // Bool appends a new field with the given key and boolean value.
 func (e Entry) Bool(key string, b bool) Entry {
+	if !e.Enabled() {
+		return e
+	}
 	e.ff = append(e.ff, Bool(key, b))
 	return e
 }
 
 // Bytes appends a new field with the given key and bytes value.
 func (e Entry) Bytes(key string, p []byte) Entry {
+	if !e.Enabled() {
+		return e
+	}
 	e.ff = append(e.ff, Bytes(key, p))
 	return e
 }
 
 // Caller appends a new field with current file and line number.
 func (e Entry) Caller(skip int) Entry {
-	e.ff = append(e.ff, Caller(skip+1))
-	return e
+	if !e.Enabled() {
+		return e
+	}
+	return e.root(Caller(skip + 1))
 }
 
 // Duration appends a new field with the given key and duration value.
 func (e Entry) Duration(key string, d time.Duration) Entry {
+	if !e.Enabled() {
+		return e
+	}
 	e.ff = append(e.ff, Duration(key, d))
 	return e
 }
 
 // Error appends given error to the entry.
 func (e Entry) Error(err error) Entry {
+	if !e.Enabled() {
+		return e
+	}
 	e.ff = append(e.ff, Error{err})
 	return e
 }
 
 // Errorf appends a new formatted error to the entry.
 func (e Entry) Errorf(format string, a ...any) Entry {
+	if !e.Enabled() {
+		return e
+	}
 	//nolint:goerr113 // Errorf is a wrapper for errorf.
 	e.ff = append(e.ff, Error{fmt.Errorf(format, a...)})
 	return e
@@ -90,48 +161,88 @@ func (e Entry) Errorf(format string, a ...any) Entry {
 
 // Float32 appends a new field with the given key and float32 value.
 func (e Entry) Float32(key string, f float32) Entry {
+	if !e.Enabled() {
+		return e
+	}
 	e.ff = append(e.ff, Float32(key, f))
 	return e
 }
 
 // Float64 appends a new field with the given key and float64 value.
 func (e Entry) Float64(key string, f float64) Entry {
+	if !e.Enabled() {
+		return e
+	}
 	e.ff = append(e.ff, Float64(key, f))
 	return e
 }
 
 // Int appends a new field with the given key and integer value.
 func (e Entry) Int(key string, i int) Entry {
+	if !e.Enabled() {
+		return e
+	}
 	e.ff = append(e.ff, Int(key, i))
 	return e
 }
 
 // Int32 appends a new field with the given key and int32 value.
 func (e Entry) Int32(key string, i int32) Entry {
+	if !e.Enabled() {
+		return e
+	}
 	e.ff = append(e.ff, Int32(key, i))
 	return e
 }
 
 // Int64 appends a new field with the given key and int64 value.
 func (e Entry) Int64(key string, i int64) Entry {
+	if !e.Enabled() {
+		return e
+	}
 	e.ff = append(e.ff, Int64(key, i))
 	return e
 }
 
 // Name appends a new field with the given logger name.
 func (e Entry) Name(name string) Entry {
-	e.ff = append(e.ff, Name(name))
+	if !e.Enabled() {
+		return e
+	}
+	return e.root(Name(name))
+}
+
+// Object appends a new field with the given key and object value.
+func (e Entry) Object(key string, v ObjectMarshaler) Entry {
+	if !e.Enabled() {
+		return e
+	}
+	e.ff = append(e.ff, Object(key, v))
 	return e
 }
 
 // Span appends a new time span field that begins at the current time.
 func (e Entry) Span() Entry {
-	e.ff = append(e.ff, Span(time.Now()))
-	return e
+	if !e.Enabled() {
+		return e
+	}
+	return e.root(Span(time.Now()))
+}
+
+// Stack appends a new stack trace field of the current goroutine, that omits
+// the given number of frames and contains at most the given number of frames.
+func (e Entry) Stack(skip, depth int) Entry {
+	if !e.Enabled() {
+		return e
+	}
+	return e.root(NewStack(skip+1, depth))
 }
 
 // String appends a new field with the given key and string value.
 func (e Entry) String(key, s string) Entry {
+	if !e.Enabled() {
+		return e
+	}
 	e.ff = append(e.ff, String(key, s))
 	return e
 }
@@ -139,48 +250,82 @@ func (e Entry) String(key, s string) Entry {
 // Stringer appends a new field with the given key and value that implements
 // stringer interface.
 func (e Entry) Stringer(key string, v fmt.Stringer) Entry {
+	if !e.Enabled() {
+		return e
+	}
 	e.ff = append(e.ff, String(key, v.String()))
 	return e
 }
 
 // Stringf appends a new field with the given key and formatted string value.
 func (e Entry) Stringf(key, format string, a ...any) Entry {
+	if !e.Enabled() {
+		return e
+	}
 	e.ff = append(e.ff, String(key, fmt.Sprintf(format, a...)))
 	return e
 }
 
 // Time appends a new field with the given key and time value.
 func (e Entry) Time(key string, t time.Time) Entry {
+	if !e.Enabled() {
+		return e
+	}
 	e.ff = append(e.ff, Time(key, t))
 	return e
 }
 
 // Timestamp appends a new field with the current time.
 func (e Entry) Timestamp() Entry {
-	e.ff = append(e.ff, Timestamp{})
-	return e
+	if !e.Enabled() {
+		return e
+	}
+	return e.root(Timestamp{})
 }
 
 // Uint appends a new field with the given key and unsigned integer value.
 func (e Entry) Uint(key string, i uint) Entry {
+	if !e.Enabled() {
+		return e
+	}
 	e.ff = append(e.ff, Uint(key, i))
 	return e
 }
 
 // Uint32 appends a new field with the given key and uint32 value.
 func (e Entry) Uint32(key string, i uint32) Entry {
+	if !e.Enabled() {
+		return e
+	}
 	e.ff = append(e.ff, Uint32(key, i))
 	return e
 }
 
 // Uint64 appends a new field with the given key and uint64 value.
 func (e Entry) Uint64(key string, i uint64) Entry {
+	if !e.Enabled() {
+		return e
+	}
 	e.ff = append(e.ff, Uint64(key, i))
 	return e
 }
 
 // With appends given fields to the entry.
 func (e Entry) With(ff ...Field) Entry {
+	if !e.Enabled() {
+		return e
+	}
 	e.ff = append(e.ff, ff...)
 	return e
 }
+
+// root appends the given field to the fields outside of groups, so metadata
+// such as the logger name or timestamp stays at the top level of the entry.
+func (e Entry) root(f Field) Entry {
+	if len(e.ns) == 0 {
+		e.ff = append(e.ff, f)
+		return e
+	}
+	e.ns[0].ff = append(e.ns[0].ff, f)
+	return e
+}
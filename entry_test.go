@@ -0,0 +1,160 @@
+package logger
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEntryGroups(t *testing.T) {
+	tests := []struct {
+		name string
+		log  func(Logger)
+		want string
+	}{
+		{
+			name: "fields",
+			log: func(log Logger) {
+				log.With().String("a", "1").Logger().Info().String("b", "2").Message("x")
+			},
+			want: `{"level":"info","a":"1","b":"2","message":"x"}`,
+		},
+		{
+			name: "group",
+			log: func(log Logger) {
+				log.With().String("a", "1").Group("g").String("b", "2").Logger().
+					Info().String("c", "3").Message("x")
+			},
+			want: `{"level":"info","a":"1","g":{"b":"2","c":"3"},"message":"x"}`,
+		},
+		{
+			name: "nested groups",
+			log: func(log Logger) {
+				log.With().Group("a").String("b", "1").Group("c").Logger().
+					Info().String("d", "2").Message("x")
+			},
+			want: `{"level":"info","a":{"b":"1","c":{"d":"2"}},"message":"x"}`,
+		},
+		{
+			name: "empty group",
+			log: func(log Logger) {
+				log.With().Group("a").Logger().Info().Message("x")
+			},
+			want: `{"level":"info","message":"x"}`,
+		},
+		{
+			name: "empty nested groups",
+			log: func(log Logger) {
+				log.With().Group("a").Group("b").Logger().Info().Message("x")
+			},
+			want: `{"level":"info","message":"x"}`,
+		},
+		{
+			name: "empty inner group",
+			log: func(log Logger) {
+				log.With().Group("a").String("b", "1").Group("c").Logger().Info().Message("x")
+			},
+			want: `{"level":"info","a":{"b":"1"},"message":"x"}`,
+		},
+		{
+			name: "group field",
+			log: func(log Logger) {
+				log.Info().With(Group("a", String("b", "1")), Group("c")).Message("x")
+			},
+			want: `{"level":"info","a":{"b":"1"},"message":"x"}`,
+		},
+		{
+			name: "shared parent",
+			log: func(log Logger) {
+				parent := log.With().Group("g").String("a", "1").Logger()
+				parent.With().String("b", "2").Logger().Info().Message("x")
+				parent.Info().Message("y")
+			},
+			want: `{"level":"info","g":{"a":"1","b":"2"},"message":"x"}` + "\n" +
+				`{"level":"info","g":{"a":"1"},"message":"y"}`,
+		},
+		{
+			name: "name",
+			log: func(log Logger) {
+				parent := log.With().Group("g").String("a", "1").Logger()
+				parent.With().Name("db").Logger().Info().Message("x")
+				parent.Info().Name("http").Message("y")
+				parent.Info().Message("z")
+			},
+			want: `{"level":"info","log":"db","g":{"a":"1"},"message":"x"}` + "\n" +
+				`{"level":"info","log":"http","g":{"a":"1"},"message":"y"}` + "\n" +
+				`{"level":"info","g":{"a":"1"},"message":"z"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, out := newTestLogger()
+			tt.log(log)
+			assertOutput(t, out, tt.want+"\n")
+		})
+	}
+}
+
+func TestEntryGroupsMetadata(t *testing.T) {
+	tests := []struct {
+		name string
+		log  func(Logger)
+		key  string
+	}{
+		{
+			name: "logger timestamp",
+			log:  func(log Logger) { log.With().Group("g").Timestamp().Logger().Info().Message("x") },
+			key:  FieldTime,
+		},
+		{
+			name: "logger caller",
+			log:  func(log Logger) { log.With().Group("g").Caller(0).Logger().Info().Message("x") },
+			key:  FieldCaller,
+		},
+		{
+			name: "logger span",
+			log:  func(log Logger) { log.With().Group("g").Span().Logger().Info().Message("x") },
+			key:  FieldSpan,
+		},
+		{
+			name: "entry timestamp",
+			log:  func(log Logger) { log.With().Group("g").Logger().Info().Timestamp().Message("x") },
+			key:  FieldTime,
+		},
+		{
+			name: "entry caller",
+			log:  func(log Logger) { log.With().Group("g").Logger().Info().Caller(0).Message("x") },
+			key:  FieldCaller,
+		},
+		{
+			name: "entry span",
+			log:  func(log Logger) { log.With().Group("g").Logger().Info().Span().Message("x") },
+			key:  FieldSpan,
+		},
+		{
+			name: "entry stack",
+			log:  func(log Logger) { log.With().Group("g").Logger().Info().Stack(0, 1).Message("x") },
+			key:  FieldStack,
+		},
+		{
+			name: "auto stack",
+			log:  func(log Logger) { log.With().StackLevel(LevelInfo).Group("g").Logger().Info().Message("x") },
+			key:  FieldStack,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, out := newTestLogger()
+			tt.log(log.With().String("a", "1").Logger())
+			var entry map[string]any
+			if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+				t.Fatalf("output = %s, %v", out, err)
+			}
+			if _, ok := entry[tt.key]; !ok {
+				t.Errorf("output = %s, want top-level %s", out, tt.key)
+			}
+			if _, ok := entry["g"]; ok {
+				t.Errorf("output = %s, want no group", out)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package logger
+
+import "fmt"
+
+// errorDepth limits the nesting of encoded error causes, so cyclic error trees
+// can't cause an infinite recursion.
+const errorDepth = 8
+
+// ErrorFielder is an optional interface of errors that contribute their own
+// fields to the encoded error object, so they carry their context into logs.
+type ErrorFielder interface {
+	// LogFields returns fields describing the error.
+	LogFields() []Field
+}
+
+// encodeError encodes the error as an object with its message, type name,
+// fields contributed by the error and a list of causes. Causes wrapped in a
+// chain are flattened into the list, while joined errors are encoded as
+// separate branches. Nil errors are encoded as a nil error.
+func encodeError(enc Encoder, key string, err error) {
+	if err == nil {
+		enc.EncodeError(key, nil)
+		return
+	}
+	encodeErrorObject(enc, key, err, true, 0)
+}
+
+func encodeErrorObject(enc Encoder, key string, err error, chain bool, depth int) {
+	enc.EncodeObject(key, func() {
+		enc.EncodeString("message", err.Error())
+		enc.EncodeString("type", fmt.Sprintf("%T", err))
+		if f, ok := err.(ErrorFielder); ok {
+			for _, f := range f.LogFields() {
+				f.Encode(enc)
+			}
+		}
+		if depth >= errorDepth {
+			return
+		}
+		if errs := errorBranches(err); len(errs) > 0 {
+			enc.EncodeArray("causes", func() {
+				for _, err := range errs {
+					encodeErrorObject(enc, "", err, true, depth+1)
+				}
+			})
+			return
+		}
+		if errs := errorChain(err); chain && len(errs) > 0 {
+			enc.EncodeArray("causes", func() {
+				for _, err := range errs {
+					encodeErrorObject(enc, "", err, false, depth+1)
+				}
+			})
+		}
+	})
+}
+
+// errorBranches returns errors joined by the given error.
+func errorBranches(err error) []error {
+	u, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return nil
+	}
+	var errs []error
+	for _, err := range u.Unwrap() {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// errorChain returns errors wrapped by the given error one inside another. The
+// chain ends with the innermost error or with the first error that joins
+// other errors.
+func errorChain(err error) []error {
+	var errs []error
+	for len(errs) < errorDepth {
+		u, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			break
+		}
+		if err = u.Unwrap(); err == nil {
+			break
+		}
+		errs = append(errs, err)
+		if _, ok := err.(interface{ Unwrap() []error }); ok {
+			break
+		}
+	}
+	return errs
+}
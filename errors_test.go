@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// joinedError implements an error that joins other errors.
+type joinedError []error
+
+func (err joinedError) Error() string {
+	var ss []string
+	for _, err := range err {
+		if err != nil {
+			ss = append(ss, err.Error())
+		}
+	}
+	return strings.Join(ss, "; ")
+}
+
+func (err joinedError) Unwrap() []error {
+	return err
+}
+
+// fieldError implements an error that contributes its own fields.
+type fieldError struct {
+	code int
+}
+
+func (err fieldError) Error() string {
+	return "failed"
+}
+
+func (err fieldError) LogFields() []Field {
+	return []Field{Int("code", err.code)}
+}
+
+// cyclicError implements an error that wraps itself.
+type cyclicError struct{}
+
+func (err *cyclicError) Error() string {
+	return "cycle"
+}
+
+func (err *cyclicError) Unwrap() error {
+	return err
+}
+
+func TestEncodeError(t *testing.T) {
+	base := errors.New("base")
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "nil", err: nil, want: `null`},
+		{name: "plain", err: base, want: `{"message":"base","type":"*errors.errorString"}`},
+		{
+			name: "chain",
+			err:  fmt.Errorf("b: %w", fmt.Errorf("a: %w", base)),
+			want: `{"message":"b: a: base","type":"*fmt.wrapError","causes":[` +
+				`{"message":"a: base","type":"*fmt.wrapError"},` +
+				`{"message":"base","type":"*errors.errorString"}]}`,
+		},
+		{
+			name: "joined",
+			err:  joinedError{fmt.Errorf("a: %w", base), nil, fieldError{code: 1}},
+			want: `{"message":"a: base; failed","type":"logger.joinedError","causes":[` +
+				`{"message":"a: base","type":"*fmt.wrapError","causes":[{"message":"base","type":"*errors.errorString"}]},` +
+				`{"message":"failed","type":"logger.fieldError","code":1}]}`,
+		},
+		{
+			name: "chain with joined",
+			err:  fmt.Errorf("c: %w", joinedError{base}),
+			want: `{"message":"c: base","type":"*fmt.wrapError","causes":[` +
+				`{"message":"base","type":"logger.joinedError","causes":[{"message":"base","type":"*errors.errorString"}]}]}`,
+		},
+		{name: "fields", err: fieldError{code: 2}, want: `{"message":"failed","type":"logger.fieldError","code":2}`},
+		{
+			name: "wrapped fields",
+			err:  fmt.Errorf("a: %w", fieldError{code: 3}),
+			want: `{"message":"a: failed","type":"*fmt.wrapError","causes":[` +
+				`{"message":"failed","type":"logger.fieldError","code":3}]}`,
+		},
+		{
+			name: "cycle",
+			err:  &cyclicError{},
+			want: `{"message":"cycle","type":"*logger.cyclicError","causes":[` +
+				strings.Repeat(`{"message":"cycle","type":"*logger.cyclicError"},`, errorDepth-1) +
+				`{"message":"cycle","type":"*logger.cyclicError"}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, out := newTestLogger()
+			log.Info().Error(tt.err).Message("x")
+			assertOutput(t, out, `{"level":"info","error":`+tt.want+`,"message":"x"}`+"\n")
+
+			log.Info().Any("e", tt.err).Message("x")
+			assertOutput(t, out, `{"level":"info","e":`+tt.want+`,"message":"x"}`+"\n")
+		})
+	}
+}
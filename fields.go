@@ -22,15 +22,40 @@ func (f FieldFunc) Encode(enc Encoder) {
 
 // Well-known field names.
 const (
-	FieldCaller  = "caller"
-	FieldError   = "error"
-	FieldLevel   = "level"
-	FieldMessage = "message"
-	FieldName    = "log"
-	FieldSpan    = "span"
-	FieldTime    = "time"
+	FieldCaller     = "caller"
+	FieldDeadline   = "deadline"
+	FieldError      = "error"
+	FieldLevel      = "level"
+	FieldMessage    = "message"
+	FieldName       = "log"
+	FieldPanic      = "panic"
+	FieldSpan       = "span"
+	FieldSpanID     = "span_id"
+	FieldStack      = "stack"
+	FieldStream     = "stream"
+	FieldTime       = "time"
+	FieldTraceFlags = "trace_flags"
+	FieldTraceID    = "trace_id"
 )
 
+// Any creates a new field with the given key and arbitrary value. Common types
+// are encoded with the corresponding encoder methods, other values such as
+// structs, maps, slices and pointers are encoded structurally using reflection.
+func Any(key string, v any) FieldFunc {
+	return func(enc Encoder) {
+		encodeAny(enc, key, v, 0)
+	}
+}
+
+// Array creates a new field with the given key and array value.
+func Array(key string, v ArrayMarshaler) FieldFunc {
+	return func(enc Encoder) {
+		enc.EncodeArray(key, func() {
+			v.MarshalLogArray(enc)
+		})
+	}
+}
+
 // Bool creates a new field with the given key and boolean value.
 func Bool(key string, b bool) FieldFunc {
 	return func(enc Encoder) {
@@ -68,9 +93,10 @@ type Error struct {
 	error
 }
 
-// Encode encodes the error with the given encoder.
+// Encode encodes the error with the given encoder as an object with message,
+// type name, causes and fields of errors implementing ErrorFielder.
 func (err Error) Encode(enc Encoder) {
-	enc.EncodeError(FieldError, err.error)
+	encodeError(enc, FieldError, err.error)
 }
 
 // Unwrap returns an underlying error.
@@ -92,6 +118,21 @@ func Float64(key string, f float64) FieldFunc {
 	}
 }
 
+// Group creates a new field with the given key that nests given fields.
+// The field is omitted if no fields are given.
+func Group(key string, ff ...Field) FieldFunc {
+	return func(enc Encoder) {
+		if len(ff) == 0 {
+			return
+		}
+		enc.EncodeObject(key, func() {
+			for _, f := range ff {
+				f.Encode(enc)
+			}
+		})
+	}
+}
+
 // Int creates a new field with the given key and integer value.
 func Int(key string, i int) FieldFunc {
 	return func(enc Encoder) {
@@ -121,6 +162,17 @@ func (msg Message) Encode(enc Encoder) {
 	enc.EncodeString(FieldMessage, string(msg))
 }
 
+// messageOf returns the message of the entry with the given fields.
+func messageOf(ff []Field) Message {
+	var msg Message
+	for _, f := range ff {
+		if field, ok := f.(Message); ok {
+			msg = field
+		}
+	}
+	return msg
+}
+
 // Name represents a logger name field.
 type Name string
 
@@ -129,6 +181,26 @@ func (name Name) Encode(enc Encoder) {
 	enc.EncodeString(FieldName, string(name))
 }
 
+// Object creates a new field with the given key and object value.
+func Object(key string, v ObjectMarshaler) FieldFunc {
+	return func(enc Encoder) {
+		enc.EncodeObject(key, func() {
+			v.MarshalLogObject(enc)
+		})
+	}
+}
+
+// nameOf returns the logger name of the entry with the given fields.
+func nameOf(ff []Field) Name {
+	var name Name
+	for _, f := range ff {
+		if field, ok := f.(Name); ok {
+			name = field
+		}
+	}
+	return name
+}
+
 // Span represents a time span field and contains a start time of the span.
 type Span time.Time
 
@@ -0,0 +1,2 @@
+// Package httplog implements a net/http middleware that logs requests.
+package httplog
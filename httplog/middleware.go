@@ -0,0 +1,178 @@
+package httplog
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/outsidedigital/logger"
+)
+
+// HeaderRequestID is a name of the header that contains the request identifier.
+const HeaderRequestID = "X-Request-ID"
+
+// Well-known field names.
+const (
+	FieldBytes     = "bytes"
+	FieldMethod    = "method"
+	FieldPath      = "path"
+	FieldRemote    = "remote"
+	FieldRequestID = "request_id"
+	FieldStatus    = "status"
+)
+
+type requestIDKey struct{}
+
+// RequestID returns the request identifier carried by the given context.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Middleware creates a new middleware that derives a logger for each request
+// and stores it in the request context, so handlers can retrieve it with
+// logger.FromContext. Once the request is handled, it logs its status, number
+// of written bytes and latency at the level depending on the status class.
+// The request identifier is taken from the X-Request-ID header or generated.
+// If the handler panics, the request is logged at the error level along with
+// the panic value and the panic is propagated.
+func Middleware(log logger.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(HeaderRequestID)
+			if id == "" {
+				id = newRequestID()
+			}
+			w.Header().Set(HeaderRequestID, id)
+
+			reqLog := log.With().
+				String(FieldMethod, r.Method).
+				String(FieldPath, r.URL.Path).
+				String(FieldRemote, r.RemoteAddr).
+				String(FieldRequestID, id).
+				Span().
+				Logger()
+			ctx := context.WithValue(r.Context(), requestIDKey{}, id)
+			ctx = logger.WithContext(ctx, reqLog)
+
+			rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+			defer func() {
+				v := recover()
+				if v == nil {
+					reqLog.EntryContext(ctx, statusLevel(rw.status)).
+						Int(FieldStatus, rw.status).
+						Int64(FieldBytes, rw.bytes).
+						Message("request completed")
+					return
+				}
+				status := rw.status
+				if !rw.wroteHeader {
+					status = http.StatusInternalServerError
+				}
+				reqLog.EntryContext(ctx, logger.LevelError).
+					Int(FieldStatus, status).
+					Int64(FieldBytes, rw.bytes).
+					Any(logger.FieldPanic, v).
+					Message("request panicked")
+				panic(v)
+			}()
+			next.ServeHTTP(rw, r.WithContext(ctx))
+		})
+	}
+}
+
+// statusLevel returns the logging priority level for the given status.
+func statusLevel(status int) logger.Level {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return logger.LevelError
+	case status >= http.StatusBadRequest:
+		return logger.LevelWarn
+	default:
+		return logger.LevelInfo
+	}
+}
+
+func newRequestID() string {
+	var id [8]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id[:])
+}
+
+// responseWriter implements a wrapper around the response writer that captures
+// the status and the number of written bytes.
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+// WriteHeader sends the response header with the given status.
+func (w *responseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write writes given bytes as a part of the response body.
+func (w *responseWriter) Write(p []byte) (int, error) {
+	w.wroteHeader = true
+	n, err := w.ResponseWriter.Write(p)
+	w.bytes += int64(n)
+	return n, err
+}
+
+// Flush sends buffered data to the client, if the response writer supports it.
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.wroteHeader = true
+		f.Flush()
+	}
+}
+
+// Hijack lets the caller take over the connection, if the response writer
+// supports it.
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	w.wroteHeader = true
+	return h.Hijack()
+}
+
+// ReadFrom reads data from the given reader and writes it as a part of the
+// response body, using the optimized path of the response writer if it
+// supports it.
+func (w *responseWriter) ReadFrom(r io.Reader) (int64, error) {
+	w.wroteHeader = true
+	var n int64
+	var err error
+	if rf, ok := w.ResponseWriter.(io.ReaderFrom); ok {
+		n, err = rf.ReadFrom(r)
+	} else {
+		n, err = io.Copy(writerOnly{w.ResponseWriter}, r)
+	}
+	w.bytes += n
+	return n, err
+}
+
+// Unwrap returns the underlying response writer.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// writerOnly hides optional interfaces of the writer, so io.Copy can't call
+// back into the response writer wrapper.
+type writerOnly struct {
+	io.Writer
+}
@@ -0,0 +1,214 @@
+package httplog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/outsidedigital/logger"
+)
+
+// newTestLogger creates a new logger that writes entries in json format to
+// the returned buffer.
+func newTestLogger() (logger.Logger, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	return logger.NewLogger().With().Writer(logger.JSONWriter(out)).Logger(), out
+}
+
+// entries decodes json entries written to the buffer.
+func entries(t *testing.T, out *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var ee []map[string]any
+	for dec := json.NewDecoder(out); dec.More(); {
+		var e map[string]any
+		if err := dec.Decode(&e); err != nil {
+			t.Fatalf("invalid entry: %v", err)
+		}
+		ee = append(ee, e)
+	}
+	return ee
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		level  string
+	}{
+		{name: "ok", status: http.StatusOK, body: "hello", level: "info"},
+		{name: "not found", status: http.StatusNotFound, level: "warn"},
+		{name: "server error", status: http.StatusInternalServerError, level: "error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, out := newTestLogger()
+			h := Middleware(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if RequestID(r.Context()) != "id" {
+					t.Errorf("RequestID() = %q, want %q", RequestID(r.Context()), "id")
+				}
+				logger.FromContext(r.Context()).Info().Message("handling")
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/path", nil)
+			req.Header.Set(HeaderRequestID, "id")
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get(HeaderRequestID); got != "id" {
+				t.Errorf("response %s = %q, want %q", HeaderRequestID, got, "id")
+			}
+			ee := entries(t, out)
+			if len(ee) != 2 {
+				t.Fatalf("got %d entries, want 2", len(ee))
+			}
+			for _, e := range ee {
+				if e[FieldRequestID] != "id" || e[FieldPath] != "/path" || e[FieldMethod] != http.MethodGet {
+					t.Errorf("entry %v lacks request fields", e)
+				}
+			}
+			e := ee[1]
+			if e["level"] != tt.level {
+				t.Errorf("level = %v, want %v", e["level"], tt.level)
+			}
+			if e[FieldStatus] != float64(tt.status) {
+				t.Errorf("status = %v, want %v", e[FieldStatus], tt.status)
+			}
+			if e[FieldBytes] != float64(len(tt.body)) {
+				t.Errorf("bytes = %v, want %v", e[FieldBytes], len(tt.body))
+			}
+		})
+	}
+}
+
+func TestMiddlewareGeneratesRequestID(t *testing.T) {
+	log, out := newTestLogger()
+	h := Middleware(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	id := rec.Header().Get(HeaderRequestID)
+	if len(id) != 16 {
+		t.Fatalf("generated request id = %q, want 16 hex digits", id)
+	}
+	if ee := entries(t, out); len(ee) != 1 || ee[0][FieldRequestID] != id {
+		t.Errorf("entries = %v, want one with request id %q", ee, id)
+	}
+}
+
+func TestMiddlewareConcurrent(t *testing.T) {
+	const n = 200
+	out := &bytes.Buffer{}
+	w := logger.NewAsyncWriter(logger.JSONWriter(out), n, logger.OverflowBlock)
+	// Fields leave spare capacity in the slice shared by request loggers.
+	log := logger.NewLogger().With().Writer(w).String("a", "1").String("b", "2").String("c", "3").Logger()
+	h := Middleware(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/%d", i), nil)
+			req.Header.Set(HeaderRequestID, fmt.Sprint(i))
+			h.ServeHTTP(httptest.NewRecorder(), req)
+		}(i)
+	}
+	wg.Wait()
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	ee := entries(t, out)
+	if len(ee) != n {
+		t.Fatalf("got %d entries, want %d", len(ee), n)
+	}
+	for _, e := range ee {
+		if e[FieldPath] != fmt.Sprintf("/%v", e[FieldRequestID]) {
+			t.Errorf("entry %v mixes fields of different requests", e)
+		}
+	}
+}
+
+func TestMiddlewarePanic(t *testing.T) {
+	log, out := newTestLogger()
+	h := Middleware(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	func() {
+		defer func() {
+			if v := recover(); v != "boom" {
+				t.Errorf("recovered %v, want boom", v)
+			}
+		}()
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}()
+
+	ee := entries(t, out)
+	if len(ee) != 1 {
+		t.Fatalf("got %d entries, want 1", len(ee))
+	}
+	e := ee[0]
+	if e["level"] != "error" || e[logger.FieldPanic] != "boom" ||
+		e[FieldStatus] != float64(http.StatusInternalServerError) {
+		t.Errorf("entry = %v, want error with panic and status 500", e)
+	}
+}
+
+func TestMiddlewareHijack(t *testing.T) {
+	log, out := newTestLogger()
+	h := Middleware(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("Hijack() error = %v", err)
+			return
+		}
+		defer conn.Close()
+		rw.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 0\r\nConnection: close\r\n\r\n")
+		rw.Flush()
+	}))
+	served := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(served)
+		h.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	<-served
+
+	if ee := entries(t, out); len(ee) != 1 {
+		t.Errorf("got %d entries, want 1", len(ee))
+	}
+}
+
+func TestMiddlewareReadFrom(t *testing.T) {
+	log, out := newTestLogger()
+	h := Middleware(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n, err := w.(io.ReaderFrom).ReadFrom(strings.NewReader("hello"))
+		if n != 5 || err != nil {
+			t.Errorf("ReadFrom() = %d, %v, want 5, nil", n, err)
+		}
+	}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Body.String() != "hello" {
+		t.Errorf("body = %q, want hello", rec.Body.String())
+	}
+	if ee := entries(t, out); len(ee) != 1 || ee[0][FieldBytes] != float64(5) {
+		t.Errorf("entries = %v, want one with 5 bytes", ee)
+	}
+}
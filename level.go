@@ -9,16 +9,46 @@ import (
 // Level represents a logging priority level.
 type Level uint
 
-// Well-known logging priority levels.
+// Well-known logging priority levels. Their numeric values don't follow the
+// order of severity, since levels added later are appended to keep the values
+// of the existing ones stable. Levels should be compared with Less.
 const (
 	LevelNone Level = iota
 	LevelError
 	LevelWarn
 	LevelInfo
 	LevelDebug
+	LevelTrace
+	LevelFatal
+	LevelPanic
 	levelCount
 )
 
+// levelRank orders well-known levels from the most severe to the least severe.
+var levelRank = [levelCount]uint{
+	LevelNone:  0,
+	LevelFatal: 1,
+	LevelPanic: 2,
+	LevelError: 3,
+	LevelWarn:  4,
+	LevelInfo:  5,
+	LevelDebug: 6,
+	LevelTrace: 7,
+}
+
+// Leveler is a generic interface of the logging priority level provider, that
+// ensures entries have a correct priority level.
+type Leveler interface {
+	Hook
+	// Level returns the current logging priority level.
+	Level() Level
+}
+
+// Level returns the logging priority level itself.
+func (lvl Level) Level() Level {
+	return lvl
+}
+
 // Encode encodes the logging priority level with the given encoder.
 func (lvl Level) Encode(enc Encoder) {
 	enc.EncodeString(FieldLevel, lvl.String())
@@ -27,16 +57,22 @@ func (lvl Level) Encode(enc Encoder) {
 // Hook intercepts the logging entry and ensure that the it has a correct
 // priority level.
 func (lvl Level) Hook(w Writer, ff ...Field) {
+	level := levelOf(ff)
+	if level.Equal(LevelNone) || lvl.Less(level) {
+		return
+	}
+	w.Write(ff...)
+}
+
+// levelOf returns the priority level of the entry with the given fields.
+func levelOf(ff []Field) Level {
 	var level Level
 	for _, f := range ff {
 		if field, ok := f.(Level); ok {
 			level = field
 		}
 	}
-	if level.Equal(LevelNone) || lvl.Less(level) {
-		return
-	}
-	w.Write(ff...)
+	return level
 }
 
 // Equal checks whether the logging priority level is equal to the given one.
@@ -44,9 +80,18 @@ func (lvl Level) Equal(other Level) bool {
 	return lvl == other
 }
 
-// Less checks whether the logging priority level is less than the given one.
+// Less checks whether the logging priority level is less than the given one,
+// i.e. whether it is more severe. Invalid levels are less severe than the
+// well-known ones.
 func (lvl Level) Less(other Level) bool {
-	return lvl < other
+	return lvl.rank() < other.rank()
+}
+
+func (lvl Level) rank() uint {
+	if lvl >= levelCount {
+		return uint(lvl)
+	}
+	return levelRank[lvl]
 }
 
 var levelOutput = []string{
@@ -55,6 +100,9 @@ var levelOutput = []string{
 	"warn",
 	"info",
 	"debug",
+	"trace",
+	"fatal",
+	"panic",
 	"invalid",
 }
 
@@ -80,12 +128,14 @@ func (lvl Level) MarshalText() ([]byte, error) {
 var levelInput = map[string]Level{
 	"none":     LevelNone,
 	"disabled": LevelNone,
+	"fatal":    LevelFatal,
+	"panic":    LevelPanic,
 	"error":    LevelError,
 	"warn":     LevelWarn,
 	"warning":  LevelWarn,
 	"info":     LevelInfo,
 	"debug":    LevelDebug,
-	"trace":    LevelDebug,
+	"trace":    LevelTrace,
 }
 
 // UnmarshalText unmarshals a text form of the logging priority level.
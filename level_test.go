@@ -0,0 +1,143 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestLevelValues(t *testing.T) {
+	tests := []struct {
+		level Level
+		want  uint
+	}{
+		{level: LevelNone, want: 0},
+		{level: LevelError, want: 1},
+		{level: LevelWarn, want: 2},
+		{level: LevelInfo, want: 3},
+		{level: LevelDebug, want: 4},
+		{level: LevelTrace, want: 5},
+		{level: LevelFatal, want: 6},
+		{level: LevelPanic, want: 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			if uint(tt.level) != tt.want {
+				t.Errorf("value = %d, want %d", tt.level, tt.want)
+			}
+		})
+	}
+}
+
+func TestLevelLess(t *testing.T) {
+	ordered := []Level{
+		LevelNone, LevelFatal, LevelPanic, LevelError, LevelWarn, LevelInfo, LevelDebug, LevelTrace, Level(100),
+	}
+	for i, lvl := range ordered {
+		for j, other := range ordered {
+			if got := lvl.Less(other); got != (i < j) {
+				t.Errorf("%v.Less(%v) = %v, want %v", lvl, other, got, i < j)
+			}
+		}
+	}
+}
+
+func TestLevelText(t *testing.T) {
+	tests := []struct {
+		text  string
+		level Level
+		want  string
+		err   error
+	}{
+		{text: "none", level: LevelNone, want: "none"},
+		{text: "disabled", level: LevelNone, want: "none"},
+		{text: "FATAL", level: LevelFatal, want: "fatal"},
+		{text: "panic", level: LevelPanic, want: "panic"},
+		{text: "error", level: LevelError, want: "error"},
+		{text: "Warning", level: LevelWarn, want: "warn"},
+		{text: "info", level: LevelInfo, want: "info"},
+		{text: "debug", level: LevelDebug, want: "debug"},
+		{text: "trace", level: LevelTrace, want: "trace"},
+		{text: "verbose", err: ErrLevelInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			var lvl Level
+			err := lvl.UnmarshalText([]byte(tt.text))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("UnmarshalText() error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if lvl != tt.level {
+				t.Errorf("UnmarshalText() = %v, want %v", lvl, tt.level)
+			}
+			text, err := lvl.MarshalText()
+			if err != nil || string(text) != tt.want {
+				t.Errorf("MarshalText() = %s, %v, want %s", text, err, tt.want)
+			}
+		})
+	}
+
+	if _, err := Level(100).MarshalText(); !errors.Is(err, ErrLevelInvalid) {
+		t.Errorf("MarshalText() of invalid level error = %v, want %v", err, ErrLevelInvalid)
+	}
+	if got := Level(100).String(); got != "invalid (100)" {
+		t.Errorf("String() of invalid level = %q", got)
+	}
+}
+
+func TestLevelHook(t *testing.T) {
+	tests := []struct {
+		level Level
+		want  []Level
+	}{
+		{level: LevelNone},
+		{level: LevelFatal, want: []Level{LevelFatal}},
+		{level: LevelError, want: []Level{LevelFatal, LevelPanic, LevelError}},
+		{level: LevelInfo, want: []Level{LevelFatal, LevelPanic, LevelError, LevelWarn, LevelInfo}},
+		{level: LevelTrace, want: []Level{
+			LevelFatal, LevelPanic, LevelError, LevelWarn, LevelInfo, LevelDebug, LevelTrace,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			var got []Level
+			w := WriterFunc(func(ff ...Field) {
+				got = append(got, levelOf(ff))
+			})
+			for _, lvl := range []Level{
+				LevelNone, LevelFatal, LevelPanic, LevelError, LevelWarn, LevelInfo, LevelDebug, LevelTrace,
+			} {
+				tt.level.Hook(w, lvl)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("passed %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("passed %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestLoggerLevels(t *testing.T) {
+	out := &bytes.Buffer{}
+	log := NewLogger().With().Writer(JSONWriter(out)).Level(LevelTrace).Logger()
+	log.Trace().Message("x")
+	assertOutput(t, out, `{"level":"trace","message":"x"}`+"\n")
+
+	func() {
+		defer func() {
+			if v := recover(); v != "y" {
+				t.Errorf("recovered %v, want y", v)
+			}
+		}()
+		log.Panic(errors.New("failed")).Message("y")
+	}()
+	assertOutput(t, out, `{"level":"panic","error":{"message":"failed","type":"*errors.errorString"},"message":"y"}`+"\n")
+}
@@ -5,9 +5,18 @@ import "os"
 // Logger implements a structured, leveled logger.
 type Logger struct {
 	w   Writer
-	lvl Level
+	lvl Leveler
 	hh  []Hook
 	ff  []Field
+	ns  []namespace
+	ee  []ContextExtractor
+	stk Level
+}
+
+// namespace represents fields of the group that encloses subsequent fields.
+type namespace struct {
+	key string
+	ff  []Field
 }
 
 // NewLogger creates a new logger that outputs to the stderr.
@@ -18,21 +27,78 @@ func NewLogger() Logger {
 	}
 }
 
-// Entry creates a new logging entry at the given level.
+// Enabled checks whether the logger is enabled at the given level.
+func (log Logger) Enabled(lvl Level) bool {
+	return !lvl.Equal(LevelNone) && !log.level().Level().Less(lvl)
+}
+
+// Entry creates a new logging entry at the given level. If the level is
+// disabled, the entry is disabled as well, so building it costs nothing.
 func (log Logger) Entry(lvl Level) Entry {
-	w := HookWriter(log.w, log.lvl)
+	return log.entry(lvl, 1)
+}
+
+// entry creates a new logging entry at the given level. The skip is a number
+// of frames omitted from the stack trace attached to the entry, not counting
+// the entry method itself.
+func (log Logger) entry(lvl Level, skip int) Entry {
+	if !log.Enabled(lvl) {
+		return Entry{}
+	}
+	w := HookWriter(log.w, log.level())
 	for _, h := range log.hh {
 		w = HookWriter(w, h)
 	}
-	return NewEntry(w).With(lvl).With(log.ff...)
+	entry := NewEntry(w).With(lvl)
+	if !log.stk.Equal(LevelNone) && !log.stk.Less(lvl) {
+		entry = entry.With(NewStack(skip+1, stackDepth))
+	}
+	if len(log.ns) == 0 {
+		return entry.With(log.ff...)
+	}
+	entry = entry.With(log.ns[0].ff...)
+	entry.ns = append(entry.ns, namespace{key: log.ns[0].key, ff: entry.ff})
+	entry.ns = append(entry.ns, log.ns[1:]...)
+	entry.ff = append([]Field(nil), log.ff...)
+	return entry
+}
+
+// Fatal creates a new logging entry at the fatal level and appends the given
+// errors to it. Once the entry message is sent, the logger is synced and the
+// process exits with status 1, even if the level is disabled.
+func (log Logger) Fatal(errs ...error) Entry {
+	entry := log.entry(LevelFatal, 1)
+	for _, err := range errs {
+		entry = entry.Error(err)
+	}
+	entry.done = func(string) {
+		log.Sync()
+		os.Exit(1)
+	}
+	return entry
+}
+
+// Panic creates a new logging entry at the panic level and appends the given
+// errors to it. Once the entry message is sent, the logger is synced and
+// a panic with the message is raised, even if the level is disabled.
+func (log Logger) Panic(errs ...error) Entry {
+	entry := log.entry(LevelPanic, 1)
+	for _, err := range errs {
+		entry = entry.Error(err)
+	}
+	entry.done = func(msg string) {
+		log.Sync()
+		panic(msg)
+	}
+	return entry
 }
 
 // Error creates a new logging entry at the error level and appends the given
 // errors to it.
 func (log Logger) Error(errs ...error) Entry {
-	entry := log.Entry(LevelError)
+	entry := log.entry(LevelError, 1)
 	for _, err := range errs {
-		entry.ff = append(entry.ff, Error{err})
+		entry = entry.Error(err)
 	}
 	return entry
 }
@@ -40,24 +106,85 @@ func (log Logger) Error(errs ...error) Entry {
 // Warn creates a new logging entry at the warn level and appends the given
 // errors to it.
 func (log Logger) Warn(errs ...error) Entry {
-	entry := log.Entry(LevelWarn)
+	entry := log.entry(LevelWarn, 1)
 	for _, err := range errs {
-		entry.ff = append(entry.ff, Error{err})
+		entry = entry.Error(err)
 	}
 	return entry
 }
 
 // Info creates a new logging entry at the info level.
 func (log Logger) Info() Entry {
-	return log.Entry(LevelInfo)
+	return log.entry(LevelInfo, 1)
 }
 
 // Debug creates a new logging entry at the debug level.
 func (log Logger) Debug() Entry {
-	return log.Entry(LevelDebug)
+	return log.entry(LevelDebug, 1)
+}
+
+// Trace creates a new logging entry at the trace level.
+func (log Logger) Trace() Entry {
+	return log.entry(LevelTrace, 1)
+}
+
+// level returns the logger priority level provider. Loggers without one have
+// all levels disabled.
+func (log Logger) level() Leveler {
+	if log.lvl == nil {
+		return LevelNone
+	}
+	return log.lvl
 }
 
-// With returns the logger configuration context.
+// With returns the logger configuration context. The derived logger never
+// shares appended fields, hooks and extractors with the original one, so
+// loggers can be derived from the shared one concurrently.
 func (log Logger) With() Options {
+	log.ff = log.ff[:len(log.ff):len(log.ff)]
+	log.hh = log.hh[:len(log.hh):len(log.hh)]
+	log.ee = log.ee[:len(log.ee):len(log.ee)]
 	return Options{log: &log}
 }
+
+// Sync flushes entries buffered by the logger hooks and writer.
+func (log Logger) Sync() error {
+	return log.walk(func(v any) error {
+		if s, ok := v.(Syncer); ok {
+			return s.Sync()
+		}
+		return nil
+	})
+}
+
+// Close flushes entries buffered by the logger hooks and writer and releases
+// their resources. Hooks and writers that can't be closed are synced. Once this
+// method is called, the logger and the ones sharing its writer should not be
+// used.
+func (log Logger) Close() error {
+	return log.walk(func(v any) error {
+		switch v := v.(type) {
+		case Closer:
+			return v.Close()
+		case Syncer:
+			return v.Sync()
+		default:
+			return nil
+		}
+	})
+}
+
+// walk calls the given function for each logger hook, starting from the
+// outermost one, and then for the logger writer. It returns the first error.
+func (log Logger) walk(fn func(any) error) error {
+	var err error
+	for i := len(log.hh) - 1; i >= 0; i-- {
+		if herr := fn(log.hh[i]); err == nil {
+			err = herr
+		}
+	}
+	if werr := fn(log.w); err == nil {
+		err = werr
+	}
+	return err
+}
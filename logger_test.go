@@ -0,0 +1,166 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// lockedBuffer implements an output that can be written concurrently.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// newTestLogger creates a new logger that writes entries in json format to
+// the returned buffer.
+func newTestLogger() (Logger, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	return NewLogger().With().Writer(JSONWriter(out)).Logger(), out
+}
+
+// assertOutput checks that the buffer contains the given output and resets it.
+func assertOutput(t *testing.T, out *bytes.Buffer, want string) {
+	t.Helper()
+	if got := out.String(); got != want {
+		t.Errorf("output = %s, want %s", got, want)
+	}
+	out.Reset()
+}
+
+func TestLoggerEnabled(t *testing.T) {
+	tests := []struct {
+		name  string
+		log   Logger
+		level Level
+		want  bool
+	}{
+		{name: "zero logger", log: Logger{}, level: LevelError, want: false},
+		{name: "more severe", log: NewLogger(), level: LevelError, want: true},
+		{name: "same", log: NewLogger(), level: LevelInfo, want: true},
+		{name: "less severe", log: NewLogger(), level: LevelDebug, want: false},
+		{name: "none", log: NewLogger(), level: LevelNone, want: false},
+		{name: "disabled logger", log: NewLogger().With().Level(LevelNone).Logger(), level: LevelError, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.log.Enabled(tt.level); got != tt.want {
+				t.Errorf("Enabled() = %v, want %v", got, tt.want)
+			}
+			if got := tt.log.Entry(tt.level).Enabled(); got != tt.want {
+				t.Errorf("Entry().Enabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoggerDisabledAllocs(t *testing.T) {
+	log, out := newTestLogger()
+	allocs := testing.AllocsPerRun(100, func() {
+		log.Debug().String("a", "1").Int("b", 2).Stringf("c", "%d", 3).Caller(0).Message("x")
+	})
+	if allocs != 0 {
+		t.Errorf("disabled entry allocates %v times, want 0", allocs)
+	}
+	assertOutput(t, out, "")
+}
+
+func BenchmarkLoggerDisabled(b *testing.B) {
+	log := NewLogger().With().Writer(JSONWriter(io.Discard)).Logger()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		log.Debug().String("a", "1").Int("b", 2).Stringf("c", "%d", 3).Caller(0).Message("x")
+	}
+}
+
+func BenchmarkLoggerEnabled(b *testing.B) {
+	log := NewLogger().With().Writer(JSONWriter(io.Discard)).Level(LevelDebug).Logger()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		log.Debug().String("a", "1").Int("b", 2).Stringf("c", "%d", 3).Caller(0).Message("x")
+	}
+}
+
+// lifecycleRecorder records calls of hooks and writers lifecycle methods.
+type lifecycleRecorder struct {
+	calls []string
+}
+
+type syncHook struct {
+	name string
+	rec  *lifecycleRecorder
+	err  error
+}
+
+func (h syncHook) Hook(w Writer, ff ...Field) {
+	w.Write(ff...)
+}
+
+func (h syncHook) Sync() error {
+	h.rec.calls = append(h.rec.calls, "sync "+h.name)
+	return h.err
+}
+
+type closeWriter struct {
+	syncHook
+}
+
+func (w closeWriter) Write(...Field) {}
+
+func (w closeWriter) Close() error {
+	w.rec.calls = append(w.rec.calls, "close "+w.name)
+	return w.err
+}
+
+func TestLoggerLifecycle(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+	tests := []struct {
+		name  string
+		close bool
+		errs  [3]error
+		calls string
+		err   error
+	}{
+		{name: "sync", calls: "sync b,sync a,sync w"},
+		{name: "close", close: true, calls: "sync b,sync a,close w"},
+		{name: "first error", errs: [3]error{errA, errB, nil}, calls: "sync b,sync a,sync w", err: errB},
+		{name: "writer error", close: true, errs: [3]error{nil, nil, errA}, calls: "sync b,sync a,close w", err: errA},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &lifecycleRecorder{}
+			log := NewLogger().With().
+				Writer(closeWriter{syncHook{name: "w", rec: rec, err: tt.errs[2]}}).
+				Hooks(syncHook{name: "a", rec: rec, err: tt.errs[0]}, syncHook{name: "b", rec: rec, err: tt.errs[1]}).
+				Logger()
+
+			var err error
+			if tt.close {
+				err = log.Close()
+			} else {
+				err = log.Sync()
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("error = %v, want %v", err, tt.err)
+			}
+			if got := strings.Join(rec.calls, ","); got != tt.calls {
+				t.Errorf("calls = %s, want %s", got, tt.calls)
+			}
+		})
+	}
+}
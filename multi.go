@@ -0,0 +1,105 @@
+package logger
+
+import "sync/atomic"
+
+// Destination represents an output of the multi writer that accepts entries at
+// the given priority level and more severe ones.
+type Destination struct {
+	Writer Writer
+	Level  Level
+}
+
+// MultiWriter implements a logging writer that dispatches entries to multiple
+// destinations. Destinations are isolated from each other, so a panicking
+// writer doesn't prevent the others from receiving the entry. Writers created
+// with NewAsyncMultiWriter also isolate destinations that stall.
+type MultiWriter struct {
+	failures uint64
+	dd       []Destination
+}
+
+// NewMultiWriter creates a new logging writer that dispatches entries to the
+// given destinations on the caller's goroutine.
+func NewMultiWriter(dd ...Destination) *MultiWriter {
+	return &MultiWriter{dd: dd}
+}
+
+// NewAsyncMultiWriter creates a new logging writer that dispatches entries to
+// the given destinations without blocking the caller. Format writers of the
+// destinations are wrapped with asynchronous writers, whose queues have the
+// given size and drop the newest entries on overflow, so a stalled destination
+// delays neither the caller nor the others. Other writers are used as they are.
+func NewAsyncMultiWriter(size int, dd ...Destination) *MultiWriter {
+	ad := make([]Destination, len(dd))
+	for i, d := range dd {
+		if fw, ok := d.Writer.(*FormatWriter); ok {
+			d.Writer = NewAsyncWriter(fw, size, OverflowDropNewest)
+		}
+		ad[i] = d
+	}
+	return &MultiWriter{dd: ad}
+}
+
+// Write writes given fields to the destinations that accept their level.
+func (w *MultiWriter) Write(ff ...Field) {
+	for _, d := range w.dd {
+		w.write(d, ff)
+	}
+}
+
+// Failures returns the number of writes to destinations that panicked.
+func (w *MultiWriter) Failures() uint64 {
+	return atomic.LoadUint64(&w.failures)
+}
+
+// Dropped returns the number of entries dropped by asynchronous destinations.
+func (w *MultiWriter) Dropped() uint64 {
+	var n uint64
+	for _, d := range w.dd {
+		if aw, ok := d.Writer.(*AsyncWriter); ok {
+			n += aw.Dropped()
+		}
+	}
+	return n
+}
+
+// Sync syncs destinations that support it and returns the first error.
+func (w *MultiWriter) Sync() error {
+	var err error
+	for _, d := range w.dd {
+		if s, ok := d.Writer.(Syncer); ok {
+			if serr := s.Sync(); err == nil {
+				err = serr
+			}
+		}
+	}
+	return err
+}
+
+// Close closes destinations that support it, syncs the others and returns
+// the first error.
+func (w *MultiWriter) Close() error {
+	var err error
+	for _, d := range w.dd {
+		var cerr error
+		switch v := d.Writer.(type) {
+		case Closer:
+			cerr = v.Close()
+		case Syncer:
+			cerr = v.Sync()
+		}
+		if err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+func (w *MultiWriter) write(d Destination, ff []Field) {
+	defer func() {
+		if recover() != nil {
+			atomic.AddUint64(&w.failures, 1)
+		}
+	}()
+	d.Level.Hook(d.Writer, ff...)
+}
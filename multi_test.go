@@ -0,0 +1,120 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+)
+
+// blockingWriter implements an output that blocks writes until it is released.
+type blockingWriter struct {
+	release chan struct{}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.release
+	return len(p), nil
+}
+
+func TestMultiWriter(t *testing.T) {
+	tests := []struct {
+		name     string
+		level    Level
+		debug    string
+		info     string
+		failures uint64
+	}{
+		{
+			name:  "error",
+			level: LevelError,
+			debug: `{"level":"error","message":"x"}` + "\n",
+			info:  `{"level":"error","message":"x"}` + "\n",
+		},
+		{
+			name:  "debug",
+			level: LevelDebug,
+			debug: `{"level":"debug","message":"x"}` + "\n",
+		},
+		{
+			name:  "trace",
+			level: LevelTrace,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			debug, info := &bytes.Buffer{}, &bytes.Buffer{}
+			w := NewMultiWriter(
+				Destination{Writer: WriterFunc(func(...Field) { panic("failed") }), Level: LevelTrace},
+				Destination{Writer: JSONWriter(debug), Level: LevelDebug},
+				Destination{Writer: JSONWriter(info), Level: LevelInfo},
+			)
+			log := NewLogger().With().Writer(w).Level(LevelTrace).Logger()
+			log.Entry(tt.level).Message("x")
+
+			assertOutput(t, debug, tt.debug)
+			assertOutput(t, info, tt.info)
+			if got := w.Failures(); got != 1 {
+				t.Errorf("Failures() = %d, want 1", got)
+			}
+		})
+	}
+}
+
+func TestAsyncMultiWriter(t *testing.T) {
+	stalled := &blockingWriter{release: make(chan struct{})}
+	out := &bytes.Buffer{}
+	w := NewAsyncMultiWriter(1,
+		Destination{Writer: JSONWriter(stalled), Level: LevelInfo},
+		Destination{Writer: JSONWriter(out), Level: LevelInfo},
+	)
+	log := NewLogger().With().Writer(w).Logger()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 10; i++ {
+			log.Info().Message("x")
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stalled destination blocks the caller")
+	}
+
+	if got := w.Dropped(); got == 0 {
+		t.Error("Dropped() = 0, want entries dropped by the stalled destination")
+	}
+	close(stalled.release)
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got := bytes.Count(out.Bytes(), []byte("\n")); uint64(got)+w.Dropped() < 10 {
+		t.Errorf("got %d entries and %d dropped, want 10 in total", got, w.Dropped())
+	}
+}
+
+func TestMultiWriterClose(t *testing.T) {
+	closed := 0
+	w := NewMultiWriter(
+		Destination{Writer: NewFormatWriter(nopCloser{io.Discard, &closed}, JSONFormat), Level: LevelInfo},
+		Destination{Writer: WriterFunc(func(...Field) {}), Level: LevelInfo},
+	)
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if closed != 1 {
+		t.Errorf("closed %d outputs, want 1", closed)
+	}
+}
+
+type nopCloser struct {
+	io.Writer
+	closed *int
+}
+
+func (c nopCloser) Close() error {
+	*c.closed++
+	return nil
+}
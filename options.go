@@ -15,12 +15,43 @@ func (o Options) Logger() Logger {
 	return *o.log
 }
 
+// Extractors appends given context extractors to the logger.
+func (o Options) Extractors(ee ...ContextExtractor) Options {
+	o.log.ee = append(o.log.ee, ee...)
+	return o
+}
+
 // Fields appends given fields to the logger.
 func (o Options) Fields(ff ...Field) Options {
 	o.log.ff = append(o.log.ff, ff...)
 	return o
 }
 
+// Group opens a new group with the given key, so subsequently appended fields,
+// including the ones of the logging entries, are nested in it. Metadata fields,
+// such as the logger name, caller, time span, stack trace and timestamp, stay
+// at the top level.
+func (o Options) Group(key string) Options {
+	n := len(o.log.ns)
+	o.log.ns = append(o.log.ns[:n:n], namespace{key: key, ff: o.log.ff})
+	o.log.ff = nil
+	return o
+}
+
+// root appends the given field to the fields outside of groups, so metadata
+// such as the logger name or timestamp stays at the top level of the entry.
+func (o Options) root(f Field) Options {
+	if len(o.log.ns) == 0 {
+		o.log.ff = append(o.log.ff, f)
+		return o
+	}
+	ns := append([]namespace(nil), o.log.ns...)
+	n := len(ns[0].ff)
+	ns[0].ff = append(ns[0].ff[:n:n], f)
+	o.log.ns = ns
+	return o
+}
+
 // Hooks appends given hooks to the logger.
 func (o Options) Hooks(hh ...Hook) Options {
 	o.log.hh = append(o.log.hh, hh...)
@@ -33,12 +64,38 @@ func (o Options) Level(lvl Level) Options {
 	return o
 }
 
+// Leveler changes the logging priority level provider, e.g. to the atomic
+// level that can be changed at runtime and shared between loggers.
+func (o Options) Leveler(lvl Leveler) Options {
+	o.log.lvl = lvl
+	return o
+}
+
+// StackLevel attaches stack traces to entries at the given level and more
+// severe ones. The none level disables stack traces.
+func (o Options) StackLevel(lvl Level) Options {
+	o.log.stk = lvl
+	return o
+}
+
 // Writer changes the logger output writer.
 func (o Options) Writer(w Writer) Options {
 	o.log.w = w
 	return o
 }
 
+// Any appends a new field with the given key and arbitrary value.
+func (o Options) Any(key string, v any) Options {
+	o.log.ff = append(o.log.ff, Any(key, v))
+	return o
+}
+
+// Array appends a new field with the given key and array value.
+func (o Options) Array(key string, v ArrayMarshaler) Options {
+	o.log.ff = append(o.log.ff, Array(key, v))
+	return o
+}
+
 // Bool appends a new field with the given key and boolean value.
 func (o Options) Bool(key string, b bool) Options {
 	o.log.ff = append(o.log.ff, Bool(key, b))
@@ -53,8 +110,7 @@ func (o Options) Bytes(key string, p []byte) Options {
 
 // Caller appends a new field with current file and line number.
 func (o Options) Caller(skip int) Options {
-	o.log.ff = append(o.log.ff, Caller(skip+1))
-	return o
+	return o.root(Caller(skip + 1))
 }
 
 // Duration appends a new field with the given key and duration value.
@@ -108,14 +164,18 @@ func (o Options) Int64(key string, i int64) Options {
 
 // Name appends a new field with the given logger name.
 func (o Options) Name(name string) Options {
-	o.log.ff = append(o.log.ff, Name(name))
+	return o.root(Name(name))
+}
+
+// Object appends a new field with the given key and object value.
+func (o Options) Object(key string, v ObjectMarshaler) Options {
+	o.log.ff = append(o.log.ff, Object(key, v))
 	return o
 }
 
 // Span appends a new time span field that begins at the current time.
 func (o Options) Span() Options {
-	o.log.ff = append(o.log.ff, Span(time.Now()))
-	return o
+	return o.root(Span(time.Now()))
 }
 
 // String appends a new field with the given key and string value.
@@ -145,8 +205,7 @@ func (o Options) Time(key string, t time.Time) Options {
 
 // Timestamp appends a new field with the current time.
 func (o Options) Timestamp() Options {
-	o.log.ff = append(o.log.ff, Timestamp{})
-	return o
+	return o.root(Timestamp{})
 }
 
 // Uint appends a new field with the given key and unsigned integer value.
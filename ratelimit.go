@@ -0,0 +1,153 @@
+package logger
+
+import (
+	"sync"
+	"time"
+)
+
+// RatePolicy represents a policy applied by the rate limiter to entries that
+// exceed the rate.
+type RatePolicy uint8
+
+// Well-known rate policies.
+const (
+	// RateDrop drops entries that exceed the rate.
+	RateDrop RatePolicy = iota
+	// RateCount drops entries that exceed the rate and writes a single entry
+	// with their number once the rate allows it.
+	RateCount
+	// RatePassErrors drops entries that exceed the rate, except the ones at
+	// the error level and more severe ones.
+	RatePassErrors
+)
+
+// RateLimiter implements a hook that limits the rate of entries using a token
+// bucket, either globally or for each logger name. Summaries of the count
+// policy written by Sync go to the writer that received the latest entry, so
+// a rate limiter must not be shared by loggers with different writers. It's
+// safe for concurrent use.
+type RateLimiter struct {
+	rate    float64
+	burst   float64
+	policy  RatePolicy
+	byName  bool
+	mu      sync.Mutex
+	buckets map[Name]*bucket
+	dropped uint64
+	w       Writer
+}
+
+type bucket struct {
+	tokens     float64
+	last       time.Time
+	suppressed uint64
+}
+
+// NewRateLimiter creates a new rate limiting hook that passes the given number
+// of entries per second with the given burst across all entries.
+func NewRateLimiter(rate float64, burst int, policy RatePolicy) *RateLimiter {
+	return &RateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		policy:  policy,
+		buckets: map[Name]*bucket{},
+	}
+}
+
+// NewNameRateLimiter creates a new rate limiting hook that passes the given
+// number of entries per second with the given burst for each logger name.
+func NewNameRateLimiter(rate float64, burst int, policy RatePolicy) *RateLimiter {
+	limiter := NewRateLimiter(rate, burst, policy)
+	limiter.byName = true
+	return limiter
+}
+
+// Hook intercepts the logging entry and passes it to the writer if the rate
+// allows it.
+func (l *RateLimiter) Hook(w Writer, ff ...Field) {
+	var name Name
+	if l.byName {
+		name = nameOf(ff)
+	}
+	now := time.Now()
+
+	l.mu.Lock()
+	l.w = w
+	b, ok := l.buckets[name]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[name] = b
+	}
+	b.tokens += now.Sub(b.last).Seconds() * l.rate
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+	b.last = now
+
+	pass := b.tokens >= 1
+	if pass {
+		b.tokens--
+	} else if l.policy == RatePassErrors {
+		level := levelOf(ff)
+		pass = !level.Equal(LevelNone) && !LevelError.Less(level)
+	}
+
+	var suppressed uint64
+	if !pass {
+		l.dropped++
+		b.suppressed++
+	} else if l.policy == RateCount {
+		suppressed, b.suppressed = b.suppressed, 0
+	}
+	l.mu.Unlock()
+
+	if suppressed > 0 {
+		l.report(w, name, suppressed)
+	}
+	if pass {
+		w.Write(ff...)
+	}
+}
+
+// Dropped returns the number of entries dropped due to the rate limit.
+func (l *RateLimiter) Dropped() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.dropped
+}
+
+// Sync writes entries with the number of suppressed entries, if the policy
+// requires it.
+func (l *RateLimiter) Sync() error {
+	if l.policy != RateCount {
+		return nil
+	}
+
+	l.mu.Lock()
+	w := l.w
+	summary := make(map[Name]uint64, len(l.buckets))
+	for name, b := range l.buckets {
+		if b.suppressed > 0 {
+			summary[name] = b.suppressed
+			b.suppressed = 0
+		}
+	}
+	l.mu.Unlock()
+
+	for name, suppressed := range summary {
+		l.report(w, name, suppressed)
+	}
+	return nil
+}
+
+func (l *RateLimiter) report(w Writer, name Name, suppressed uint64) {
+	ff := []Field{LevelWarn}
+	if name != "" {
+		ff = append(ff, name)
+	}
+	ff = append(ff,
+		Uint64("suppressed", suppressed),
+		Message("log entries suppressed by rate limit"),
+	)
+	w.Write(ff...)
+}
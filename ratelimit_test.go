@@ -0,0 +1,98 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RatePolicy
+		passed  int
+		errors  int
+		dropped uint64
+		summary string
+	}{
+		{name: "drop", policy: RateDrop, passed: 2, dropped: 4},
+		{name: "count", policy: RateCount, passed: 2, dropped: 4, summary: `"suppressed":4`},
+		{name: "pass errors", policy: RatePassErrors, passed: 2, errors: 1, dropped: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			l := NewRateLimiter(0, 2, tt.policy)
+			log := NewLogger().With().Writer(JSONWriter(out)).Hooks(l).Logger()
+			for i := 0; i < 5; i++ {
+				log.Info().Message("x")
+			}
+			log.Error(nil).Message("y")
+
+			if got := strings.Count(out.String(), `"message":"x"`); got != tt.passed {
+				t.Errorf("passed %d entries, want %d", got, tt.passed)
+			}
+			if got := strings.Count(out.String(), `"message":"y"`); got != tt.errors {
+				t.Errorf("passed %d error entries, want %d", got, tt.errors)
+			}
+			if got := l.Dropped(); got != tt.dropped {
+				t.Errorf("Dropped() = %d, want %d", got, tt.dropped)
+			}
+
+			out.Reset()
+			if err := log.Sync(); err != nil {
+				t.Fatalf("Sync() error = %v", err)
+			}
+			if tt.summary == "" {
+				assertOutput(t, out, "")
+				return
+			}
+			want := `{"level":"warn",` + tt.summary + `,"message":"log entries suppressed by rate limit"}` + "\n"
+			assertOutput(t, out, want)
+		})
+	}
+}
+
+func TestRateLimiterRefill(t *testing.T) {
+	out := &bytes.Buffer{}
+	l := NewRateLimiter(50, 1, RateCount)
+	log := NewLogger().With().Writer(JSONWriter(out)).Hooks(l).Logger()
+	log.Info().Message("x")
+	log.Info().Message("x")
+	time.Sleep(40 * time.Millisecond)
+	log.Info().Message("x")
+
+	if got := strings.Count(out.String(), `"message":"x"`); got != 2 {
+		t.Errorf("passed %d entries, want 2", got)
+	}
+	if !strings.Contains(out.String(), `"suppressed":1`) {
+		t.Errorf("output = %s, want the suppressed count once the rate allows it", out.String())
+	}
+}
+
+func TestNameRateLimiter(t *testing.T) {
+	out := &bytes.Buffer{}
+	l := NewNameRateLimiter(0, 1, RateCount)
+	log := NewLogger().With().Writer(JSONWriter(out)).Hooks(l).Logger()
+	a := log.With().Name("a").Logger()
+	b := log.With().Name("b").Logger()
+	for i := 0; i < 3; i++ {
+		a.Info().Message("x")
+		b.Info().Message("x")
+	}
+
+	if got := strings.Count(out.String(), `"message":"x"`); got != 2 {
+		t.Errorf("passed %d entries, want one for each name", got)
+	}
+	out.Reset()
+	if err := log.Sync(); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	for _, name := range []string{"a", "b"} {
+		want := `{"level":"warn","log":"` + name + `","suppressed":2,`
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output = %s, want %s", out.String(), want)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package logger
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// anyDepth limits the nesting of values encoded using reflection, so cyclic
+// data structures can't cause an infinite recursion.
+const anyDepth = 32
+
+func encodeAny(enc Encoder, key string, v any, depth int) {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		encodeNil(enc, key)
+		return
+	}
+	switch v := v.(type) {
+	case nil:
+		encodeNil(enc, key)
+	case bool:
+		enc.EncodeBool(key, v)
+	case []byte:
+		enc.EncodeBytes(key, v)
+	case time.Duration:
+		enc.EncodeDuration(key, v)
+	case error:
+		encodeError(enc, key, v)
+	case float32:
+		enc.EncodeFloat32(key, v)
+	case float64:
+		enc.EncodeFloat64(key, v)
+	case int:
+		enc.EncodeInt(key, v)
+	case int32:
+		enc.EncodeInt32(key, v)
+	case int64:
+		enc.EncodeInt64(key, v)
+	case string:
+		enc.EncodeString(key, v)
+	case time.Time:
+		enc.EncodeTime(key, v)
+	case uint:
+		enc.EncodeUint(key, v)
+	case uint32:
+		enc.EncodeUint32(key, v)
+	case uint64:
+		enc.EncodeUint64(key, v)
+	case ArrayMarshaler:
+		enc.EncodeArray(key, func() {
+			v.MarshalLogArray(enc)
+		})
+	case ObjectMarshaler:
+		enc.EncodeObject(key, func() {
+			v.MarshalLogObject(enc)
+		})
+	case fmt.Stringer:
+		enc.EncodeString(key, v.String())
+	default:
+		encodeValue(enc, key, reflect.ValueOf(v), depth)
+	}
+}
+
+//nolint:cyclop // The switch covers all kinds of values.
+func encodeValue(enc Encoder, key string, v reflect.Value, depth int) {
+	if depth >= anyDepth {
+		enc.EncodeString(key, v.Type().String())
+		return
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		enc.EncodeBool(key, v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		enc.EncodeInt64(key, v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		enc.EncodeUint64(key, v.Uint())
+	case reflect.Float32:
+		enc.EncodeFloat32(key, float32(v.Float()))
+	case reflect.Float64:
+		enc.EncodeFloat64(key, v.Float())
+	case reflect.String:
+		enc.EncodeString(key, v.String())
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			encodeNil(enc, key)
+			return
+		}
+		encodeElem(enc, key, v.Elem(), depth+1)
+	case reflect.Struct:
+		enc.EncodeObject(key, func() {
+			encodeStruct(enc, v, depth+1)
+		})
+	case reflect.Map:
+		if v.IsNil() {
+			encodeNil(enc, key)
+			return
+		}
+		enc.EncodeObject(key, func() {
+			encodeMap(enc, v, depth+1)
+		})
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			encodeNil(enc, key)
+			return
+		}
+		enc.EncodeArray(key, func() {
+			for i := 0; i < v.Len(); i++ {
+				encodeElem(enc, "", v.Index(i), depth+1)
+			}
+		})
+	default:
+		enc.EncodeString(key, fmt.Sprint(v))
+	}
+}
+
+func encodeElem(enc Encoder, key string, v reflect.Value, depth int) {
+	if v.CanInterface() {
+		encodeAny(enc, key, v.Interface(), depth)
+		return
+	}
+	encodeValue(enc, key, v, depth)
+}
+
+// encodeStruct encodes exported fields of the struct. Field names can be
+// overridden or the fields can be skipped with the json tag.
+func encodeStruct(enc Encoder, v reflect.Value, depth int) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key := field.Name
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			name, _, _ := strings.Cut(tag, ",")
+			if name == "-" {
+				continue
+			}
+			if name != "" {
+				key = name
+			}
+		}
+		encodeElem(enc, key, v.Field(i), depth)
+	}
+}
+
+// encodeMap encodes entries of the map sorted by their keys.
+func encodeMap(enc Encoder, v reflect.Value, depth int) {
+	keys := make([]string, 0, v.Len())
+	values := make(map[string]reflect.Value, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		key := fmt.Sprint(iter.Key())
+		keys = append(keys, key)
+		values[key] = iter.Value()
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		encodeElem(enc, key, values[key], depth)
+	}
+}
+
+// encodeNil encodes a nil value as a nil error, which is encoded as null or
+// omitted depending on the encoder.
+func encodeNil(enc Encoder, key string) {
+	enc.EncodeError(key, nil)
+}
@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type anyStruct struct {
+	Name    string `json:"name"`
+	Skipped string `json:"-"`
+	Count   int    `json:",omitempty"`
+	private int
+	Nested  *anyStruct
+}
+
+type anyNode struct {
+	Next *anyNode
+}
+
+type anyStringer struct{}
+
+func (anyStringer) String() string {
+	return "stringer"
+}
+
+func TestAny(t *testing.T) {
+	cyclic := &anyNode{}
+	cyclic.Next = cyclic
+	ch := make(chan int)
+
+	tests := []struct {
+		name string
+		v    any
+		want string
+	}{
+		{name: "nil", v: nil, want: `null`},
+		{name: "nil pointer", v: (*anyStruct)(nil), want: `null`},
+		{name: "bool", v: true, want: `true`},
+		{name: "int", v: 1, want: `1`},
+		{name: "int8", v: int8(-2), want: `-2`},
+		{name: "uint16", v: uint16(3), want: `3`},
+		{name: "float64", v: 1.5, want: `1.5`},
+		{name: "string", v: "a", want: `"a"`},
+		{name: "duration", v: 1500 * time.Millisecond, want: `1.5`},
+		{name: "time", v: time.Date(2022, 4, 1, 12, 0, 0, 0, time.UTC), want: `"2022-04-01T12:00:00Z"`},
+		{name: "stringer", v: anyStringer{}, want: `"stringer"`},
+		{
+			name: "object marshaler",
+			v:    ObjectFunc(func(enc Encoder) { enc.EncodeInt("a", 1) }),
+			want: `{"a":1}`,
+		},
+		{
+			name: "array marshaler",
+			v:    ArrayFunc(func(enc Encoder) { enc.EncodeInt("", 1) }),
+			want: `[1]`,
+		},
+		{
+			name: "struct",
+			v:    anyStruct{Name: "a", Skipped: "b", Count: 1, private: 2, Nested: &anyStruct{Name: "c"}},
+			want: `{"name":"a","Count":1,"Nested":{"name":"c","Count":0,"Nested":null}}`,
+		},
+		{name: "map", v: map[string]int{"b": 2, "a": 1}, want: `{"a":1,"b":2}`},
+		{name: "nil map", v: map[string]int(nil), want: `null`},
+		{name: "slice", v: []any{1, "a", nil}, want: `[1,"a",null]`},
+		{name: "nil slice", v: []int(nil), want: `null`},
+		{name: "array", v: [2]bool{true, false}, want: `[true,false]`},
+		{name: "pointer", v: &[]int{1}, want: `[1]`},
+		{name: "unsupported", v: ch, want: strconv.Quote(fmt.Sprint(ch))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, out := newTestLogger()
+			log.Info().Any("v", tt.v).Message("x")
+			assertOutput(t, out, `{"level":"info","v":`+tt.want+`,"message":"x"}`+"\n")
+		})
+	}
+
+	t.Run("cyclic", func(t *testing.T) {
+		log, out := newTestLogger()
+		log.Info().Any("v", cyclic).Message("x")
+		if got := out.String(); strings.Count(got, `"Next"`) > anyDepth || !strings.Contains(got, `"*logger.anyNode"`) {
+			t.Errorf("output = %s, want nesting limited to %d levels", got, anyDepth)
+		}
+	})
+}
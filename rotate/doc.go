@@ -0,0 +1,3 @@
+// Package rotate implements a file output that rotates by size and time and
+// retains a limited number of backups.
+package rotate
@@ -0,0 +1,339 @@
+package rotate
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config represents a rotation configuration of the file.
+type Config struct {
+	// MaxSize is a maximum size of the file in bytes. Zero disables rotation
+	// by size.
+	MaxSize int64
+	// Interval is a maximum time the file is written before it is rotated.
+	// Zero disables rotation by time.
+	Interval time.Duration
+	// MaxBackups is a maximum number of backups to retain. Zero retains all
+	// backups.
+	MaxBackups int
+	// MaxAge is a maximum age of backups to retain. Zero retains all backups.
+	MaxAge time.Duration
+	// Compress enables gzip compression of backups.
+	Compress bool
+}
+
+// backupLayout is a time layout of the backup name suffix.
+const backupLayout = "20060102T150405.000"
+
+// rename renames files, it is replaced in tests to simulate failures.
+var rename = os.Rename
+
+// File implements a file output that rotates the file according to the given
+// configuration. Rotated files are renamed to backups, whose names contain the
+// rotation time, e.g. app-20220401T120000.000.log for app.log. Backups rotated
+// within the same millisecond are distinguished by a sequence number, e.g.
+// app-20220401T120000.000-1.log.
+type File struct {
+	name   string
+	cfg    Config
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	opened time.Time
+	wg     sync.WaitGroup
+	cmu    sync.Mutex
+	err    error
+}
+
+// Open opens the file with the given name for appending, creating it and its
+// directory if necessary.
+func Open(name string, cfg Config) (*File, error) {
+	f := &File{name: name, cfg: cfg}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write writes given bytes to the file, rotating it beforehand if the size or
+// time limit is reached.
+func (f *File) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+	if f.exceeds(len(p)) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Rotate rotates the file regardless of the limits.
+func (f *File) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return os.ErrClosed
+	}
+	return f.rotate()
+}
+
+// Reopen closes the file and opens it again by name. It should be called once
+// the file is moved by an external tool, such as logrotate, usually upon
+// receiving the SIGHUP signal.
+func (f *File) Reopen() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file != nil {
+		if err := f.file.Close(); err != nil {
+			return err
+		}
+		f.file = nil
+	}
+	return f.open()
+}
+
+// Sync commits the file contents to the stable storage.
+func (f *File) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return os.ErrClosed
+	}
+	return f.file.Sync()
+}
+
+// Close closes the file and waits until backups are compressed and cleaned
+// up. It returns the first error occurred while processing backups.
+func (f *File) Close() error {
+	f.mu.Lock()
+	var err error
+	if f.file != nil {
+		err = f.file.Close()
+		f.file = nil
+	}
+	f.mu.Unlock()
+
+	f.wg.Wait()
+	if err == nil {
+		err = f.err
+	}
+	return err
+}
+
+func (f *File) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.name), 0o755); err != nil {
+		return err
+	}
+	//nolint:gosec // The file name is provided by the application.
+	file, err := os.OpenFile(f.name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	f.opened = time.Now()
+	return nil
+}
+
+func (f *File) exceeds(n int) bool {
+	if f.cfg.MaxSize > 0 && f.size > 0 && f.size+int64(n) > f.cfg.MaxSize {
+		return true
+	}
+	return f.cfg.Interval > 0 && time.Since(f.opened) >= f.cfg.Interval
+}
+
+// rotate renames the file to a backup and opens a new one. If it fails, the
+// original file is opened again, so subsequent writes can proceed.
+func (f *File) rotate() error {
+	err := f.file.Close()
+	f.file = nil
+	if err != nil {
+		return f.reopen(err)
+	}
+
+	backup, err := f.backupName(time.Now())
+	if err != nil {
+		return f.reopen(err)
+	}
+	if err := rename(f.name, backup); err != nil && !os.IsNotExist(err) {
+		return f.reopen(err)
+	}
+	if err := f.open(); err != nil {
+		_ = rename(backup, f.name)
+		return f.reopen(err)
+	}
+
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		f.cleanup(backup)
+	}()
+	return nil
+}
+
+// cleanup compresses the given backup and removes backups exceeding the
+// retention limits.
+func (f *File) cleanup(backup string) {
+	f.cmu.Lock()
+	defer f.cmu.Unlock()
+
+	if f.cfg.Compress {
+		f.fail(compress(backup))
+	}
+	if f.cfg.MaxBackups <= 0 && f.cfg.MaxAge <= 0 {
+		return
+	}
+
+	backups, err := f.backups()
+	if err != nil {
+		f.fail(err)
+		return
+	}
+	for i, b := range backups {
+		expired := f.cfg.MaxAge > 0 && time.Since(b.time) > f.cfg.MaxAge
+		if expired || (f.cfg.MaxBackups > 0 && i >= f.cfg.MaxBackups) {
+			f.fail(os.Remove(b.name))
+		}
+	}
+}
+
+// reopen opens the file after the failed rotation and returns the given error.
+func (f *File) reopen(err error) error {
+	_ = f.open()
+	return err
+}
+
+func (f *File) fail(err error) {
+	if err != nil && f.err == nil {
+		f.err = err
+	}
+}
+
+// backupName returns a name of the backup rotated at the given time, that
+// doesn't collide with existing backups, compressed or not.
+func (f *File) backupName(t time.Time) (string, error) {
+	ext := filepath.Ext(f.name)
+	base := strings.TrimSuffix(f.name, ext) + "-" + t.Format(backupLayout)
+	for seq := 0; ; seq++ {
+		name := base + ext
+		if seq > 0 {
+			name = base + "-" + strconv.Itoa(seq) + ext
+		}
+		exists, err := fileExists(name)
+		if err == nil && !exists {
+			exists, err = fileExists(name + ".gz")
+		}
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return name, nil
+		}
+	}
+}
+
+type backup struct {
+	name string
+	time time.Time
+	seq  int
+}
+
+// backups returns existing backups sorted from the newest to the oldest.
+func (f *File) backups() ([]backup, error) {
+	dir := filepath.Dir(f.name)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	ext := filepath.Ext(f.name)
+	prefix := strings.TrimSuffix(filepath.Base(f.name), ext) + "-"
+	var backups []backup
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".gz")
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)
+		stamp, suffix, found := strings.Cut(stamp, "-")
+		t, err := time.ParseInLocation(backupLayout, stamp, time.Local)
+		if err != nil {
+			continue
+		}
+		var seq int
+		if found {
+			if seq, err = strconv.Atoi(suffix); err != nil || seq <= 0 {
+				continue
+			}
+		}
+		backups = append(backups, backup{name: filepath.Join(dir, entry.Name()), time: t, seq: seq})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		if backups[i].time.Equal(backups[j].time) {
+			return backups[i].seq > backups[j].seq
+		}
+		return backups[i].time.After(backups[j].time)
+	})
+	return backups, nil
+}
+
+// fileExists checks whether the file with the given name exists.
+func fileExists(name string) (bool, error) {
+	_, err := os.Lstat(name)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// compress compresses the file with the given name into a gzip file with the
+// same name and the .gz suffix and removes the original file.
+func compress(name string) error {
+	//nolint:gosec // The file name is built from the application one.
+	src, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	//nolint:gosec // The file name is built from the application one.
+	dst, err := os.OpenFile(name+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(dst)
+	if _, err = io.Copy(zw, src); err == nil {
+		err = zw.Close()
+	}
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(name + ".gz")
+		return err
+	}
+	src.Close()
+	return os.Remove(name)
+}
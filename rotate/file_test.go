@@ -0,0 +1,215 @@
+package rotate
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// readLines returns lines of the file and its backups, compressed or not.
+func readLines(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	for _, entry := range entries {
+		file, err := os.Open(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var r io.Reader = file
+		if strings.HasSuffix(entry.Name(), ".gz") {
+			if r, err = gzip.NewReader(file); err != nil {
+				t.Fatal(err)
+			}
+		}
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		file.Close()
+	}
+	return lines
+}
+
+func TestFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		writes  int
+		files   int
+		lines   int
+		gzipped bool
+	}{
+		{name: "no rotation", cfg: Config{}, writes: 10, files: 1, lines: 10},
+		{name: "size", cfg: Config{MaxSize: 10}, writes: 200, files: 200, lines: 200},
+		{name: "size with two lines", cfg: Config{MaxSize: 22}, writes: 10, files: 5, lines: 10},
+		{name: "max backups", cfg: Config{MaxSize: 10, MaxBackups: 3}, writes: 10, files: 4, lines: 4},
+		{name: "compress", cfg: Config{MaxSize: 10, Compress: true}, writes: 5, files: 5, lines: 5, gzipped: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			f, err := Open(filepath.Join(dir, "app.log"), tt.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < tt.writes; i++ {
+				if _, err := f.Write([]byte("0123456789\n")); err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+			}
+			if err := f.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != tt.files {
+				t.Errorf("got %d files, want %d", len(entries), tt.files)
+			}
+			if got := readLines(t, dir); len(got) != tt.lines {
+				t.Errorf("got %d lines, want %d", len(got), tt.lines)
+			}
+			for _, entry := range entries {
+				if entry.Name() != "app.log" && strings.HasSuffix(entry.Name(), ".gz") != tt.gzipped {
+					t.Errorf("backup %s compressed = %v, want %v", entry.Name(), !tt.gzipped, tt.gzipped)
+				}
+			}
+		})
+	}
+}
+
+func TestFileInterval(t *testing.T) {
+	dir := t.TempDir()
+	f, err := Open(filepath.Join(dir, "app.log"), Config{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	f.Write([]byte("first\n"))
+	time.Sleep(2 * time.Millisecond)
+	f.Write([]byte("second\n"))
+
+	data, err := os.ReadFile(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "second\n" {
+		t.Errorf("file contains %q, want the second line only", data)
+	}
+}
+
+func TestFileRotateFailure(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.log")
+	f, err := Open(name, Config{MaxSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	errRename := errors.New("rename failed")
+	rename = func(string, string) error {
+		return errRename
+	}
+	f.Write([]byte("0123456789\n"))
+	if _, err := f.Write([]byte("0123456789\n")); !errors.Is(err, errRename) {
+		t.Errorf("Write() error = %v, want %v", err, errRename)
+	}
+	rename = os.Rename
+
+	if _, err := f.Write([]byte("0123456789\n")); err != nil {
+		t.Errorf("Write() after failed rotation error = %v", err)
+	}
+	if got := readLines(t, dir); len(got) != 2 {
+		t.Errorf("got %d lines, want 2", len(got))
+	}
+}
+
+func TestFileReopen(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.log")
+	f, err := Open(name, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write([]byte("first\n"))
+	if err := os.Rename(name, name+".1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Reopen(); err != nil {
+		t.Fatalf("Reopen() error = %v", err)
+	}
+	f.Write([]byte("second\n"))
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "second\n" {
+		t.Errorf("file contains %q, want the second line only", data)
+	}
+	if _, err := f.Write([]byte("third\n")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("Write() after Close error = %v, want %v", err, os.ErrClosed)
+	}
+}
+
+func TestFileBackups(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"app-20220401T120000.000.log",
+		"app-20220401T120000.000-1.log.gz",
+		"app-20220401T120000.000-2.log",
+		"app-20220401T110000.000.log",
+		"app-invalid.log",
+		"app-20220401T120000.000-x.log",
+		"other.log",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	f := &File{name: filepath.Join(dir, "app.log")}
+	backups, err := f.backups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"app-20220401T120000.000-2.log",
+		"app-20220401T120000.000-1.log.gz",
+		"app-20220401T120000.000.log",
+		"app-20220401T110000.000.log",
+	}
+	if len(backups) != len(want) {
+		t.Fatalf("got %d backups, want %d", len(backups), len(want))
+	}
+	for i, b := range backups {
+		if filepath.Base(b.name) != want[i] {
+			t.Errorf("backup %d = %s, want %s", i, filepath.Base(b.name), want[i])
+		}
+	}
+
+	name, err := f.backupName(time.Date(2022, 4, 1, 12, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(name) != "app-20220401T120000.000-3.log" {
+		t.Errorf("backupName() = %s, want the next sequence number", filepath.Base(name))
+	}
+}
@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LevelRouter implements a logging priority level provider that applies
+// different levels to entries depending on their logger name. It should be
+// set as the logger level provider, so entries of the most verbose name are
+// not filtered beforehand.
+type LevelRouter struct {
+	lvl   Level
+	rules []levelRule
+}
+
+type levelRule struct {
+	name string
+	tree bool
+	lvl  Level
+}
+
+// ParseLevelRouter parses a comma-separated list of logging priority levels,
+// such as "info,db=debug,http=warn". The level without a name is applied to
+// entries that match no other names. The name ending with ".*", such as
+// "svc.db.*", matches the name itself and all names nested in it. The most
+// specific name takes precedence.
+func ParseLevelRouter(s string) (*LevelRouter, error) {
+	r := &LevelRouter{}
+	if err := r.UnmarshalText([]byte(s)); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// UnmarshalText unmarshals a comma-separated list of logging priority levels.
+// It should not be called once the router is in use.
+func (r *LevelRouter) UnmarshalText(text []byte) error {
+	lvl := LevelInfo
+	var rules []levelRule
+	for _, part := range strings.Split(string(text), ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			if err := lvl.UnmarshalText([]byte(part)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		rule := levelRule{name: strings.TrimSpace(name)}
+		if strings.HasSuffix(rule.name, ".*") {
+			rule.name, rule.tree = strings.TrimSuffix(rule.name, ".*"), true
+		}
+		if rule.name == "" {
+			return fmt.Errorf("%s: %w", part, ErrLevelInvalid)
+		}
+		if err := rule.lvl.UnmarshalText([]byte(strings.TrimSpace(value))); err != nil {
+			return err
+		}
+		rules = append(rules, rule)
+	}
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		if len(rules[i].name) != len(rules[j].name) {
+			return len(rules[i].name) > len(rules[j].name)
+		}
+		return !rules[i].tree && rules[j].tree
+	})
+	r.lvl, r.rules = lvl, rules
+	return nil
+}
+
+// Level returns the most verbose logging priority level of the router.
+func (r *LevelRouter) Level() Level {
+	lvl := r.lvl
+	for _, rule := range r.rules {
+		if lvl.Less(rule.lvl) {
+			lvl = rule.lvl
+		}
+	}
+	return lvl
+}
+
+// LevelOf returns the logging priority level applied to the given name.
+func (r *LevelRouter) LevelOf(name string) Level {
+	for _, rule := range r.rules {
+		if rule.match(name) {
+			return rule.lvl
+		}
+	}
+	return r.lvl
+}
+
+// Hook intercepts the logging entry and passes it to the writer if its level
+// is enabled for its logger name.
+func (r *LevelRouter) Hook(w Writer, ff ...Field) {
+	r.LevelOf(string(nameOf(ff))).Hook(w, ff...)
+}
+
+func (rule levelRule) match(name string) bool {
+	if name == rule.name {
+		return true
+	}
+	return rule.tree && strings.HasPrefix(name, rule.name+".")
+}
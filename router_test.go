@@ -0,0 +1,116 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLevelRouterLevelOf(t *testing.T) {
+	r, err := ParseLevelRouter("warn, db=debug, svc.db.*=trace, svc=error, svc.*=info")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		want Level
+	}{
+		{name: "", want: LevelWarn},
+		{name: "http", want: LevelWarn},
+		{name: "db", want: LevelDebug},
+		{name: "db.pool", want: LevelWarn},
+		{name: "svc", want: LevelError},
+		{name: "svc.http", want: LevelInfo},
+		{name: "svc.db", want: LevelTrace},
+		{name: "svc.db.pool", want: LevelTrace},
+		{name: "svcdb", want: LevelWarn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.LevelOf(tt.name); got != tt.want {
+				t.Errorf("LevelOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if got := r.Level(); got != LevelTrace {
+		t.Errorf("Level() = %v, want the most verbose level", got)
+	}
+}
+
+func TestParseLevelRouter(t *testing.T) {
+	tests := []struct {
+		text  string
+		level Level
+		err   error
+	}{
+		{text: "", level: LevelInfo},
+		{text: "debug", level: LevelDebug},
+		{text: "db=fatal", level: LevelInfo},
+		{text: "verbose", err: ErrLevelInvalid},
+		{text: "db=verbose", err: ErrLevelInvalid},
+		{text: "=debug", err: ErrLevelInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			r, err := ParseLevelRouter(tt.text)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ParseLevelRouter() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && r.LevelOf("other") != tt.level {
+				t.Errorf("LevelOf() = %v, want %v", r.LevelOf("other"), tt.level)
+			}
+		})
+	}
+}
+
+func TestLevelRouterHook(t *testing.T) {
+	r, err := ParseLevelRouter("info,db=debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := &bytes.Buffer{}
+	log := NewLogger().With().Writer(JSONWriter(out)).Leveler(r).Logger()
+	log.With().Name("db").Logger().Debug().Message("x")
+	log.With().Name("http").Logger().Debug().Message("y")
+	log.Debug().Message("z")
+	log.Info().Message("w")
+
+	got := out.String()
+	if !strings.Contains(got, `"message":"x"`) || strings.Contains(got, `"message":"y"`) ||
+		strings.Contains(got, `"message":"z"`) || !strings.Contains(got, `"message":"w"`) {
+		t.Errorf("output = %s, want debug entries of db and info entries only", got)
+	}
+}
+
+func TestLevelRouterGroup(t *testing.T) {
+	r, err := ParseLevelRouter("warn,db=debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := &bytes.Buffer{}
+	log := NewLogger().With().Writer(JSONWriter(out)).Leveler(r).Logger()
+	tests := []struct {
+		name string
+		log  Logger
+		want string
+	}{
+		{
+			name: "logger name",
+			log:  log.With().Group("g").String("a", "1").Name("db").Logger(),
+			want: `{"level":"debug","log":"db","g":{"a":"1"},"message":"x"}` + "\n",
+		},
+		{
+			name: "nested groups",
+			log:  log.With().Group("g").Group("h").String("a", "1").Name("db").Logger(),
+			want: `{"level":"debug","log":"db","g":{"h":{"a":"1"}},"message":"x"}` + "\n",
+		},
+		{name: "other name", log: log.With().Group("g").Name("http").Logger(), want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.log.Debug().Message("x")
+			assertOutput(t, out, tt.want)
+		})
+	}
+}
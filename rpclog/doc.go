@@ -0,0 +1,4 @@
+// Package rpclog implements interceptor-like helpers that log remote procedure
+// calls. It has no dependencies on rpc frameworks, so adapters for them, such
+// as grpc interceptors, can be layered on top.
+package rpclog
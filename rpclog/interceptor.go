@@ -0,0 +1,103 @@
+package rpclog
+
+import (
+	"context"
+	"errors"
+
+	"github.com/outsidedigital/logger"
+)
+
+// Well-known field names.
+const (
+	FieldCode   = "code"
+	FieldMethod = "method"
+	FieldPeer   = "peer"
+)
+
+// Well-known outcome codes.
+const (
+	CodeOK               = "ok"
+	CodeCanceled         = "canceled"
+	CodeDeadlineExceeded = "deadline_exceeded"
+	CodeUnknown          = "unknown"
+)
+
+// Call represents a remote procedure call.
+type Call struct {
+	// Method is a full name of the called method.
+	Method string
+	// Peer is an address of the remote side.
+	Peer string
+}
+
+// Classifier is a function that classifies the call error into an outcome
+// code and the logging priority level of the call entry.
+type Classifier func(error) (string, logger.Level)
+
+// Classify classifies the call error. Successful calls are logged at the info
+// level, canceled and timed out ones at the warn level and the others at the
+// error level.
+func Classify(err error) (string, logger.Level) {
+	switch {
+	case err == nil:
+		return CodeOK, logger.LevelInfo
+	case errors.Is(err, context.Canceled):
+		return CodeCanceled, logger.LevelWarn
+	case errors.Is(err, context.DeadlineExceeded):
+		return CodeDeadlineExceeded, logger.LevelWarn
+	default:
+		return CodeUnknown, logger.LevelError
+	}
+}
+
+// Interceptor implements a helper that logs remote procedure calls.
+type Interceptor struct {
+	log      logger.Logger
+	classify Classifier
+}
+
+// NewInterceptor creates a new interceptor that logs calls with the given
+// logger and classifies their errors with the given classifier. If the
+// classifier is nil, Classify is used.
+func NewInterceptor(log logger.Logger, classify Classifier) *Interceptor {
+	if classify == nil {
+		classify = Classify
+	}
+	return &Interceptor{log: log, classify: classify}
+}
+
+// Unary invokes the given function with a context that carries the logger
+// derived for the call and logs the call outcome and duration.
+func (i *Interceptor) Unary(ctx context.Context, call Call, fn func(context.Context) error) error {
+	return i.intercept(ctx, call, fn, "call completed")
+}
+
+// Stream invokes the given function with a context that carries the logger
+// derived for the streaming call and logs the stream start, outcome and
+// duration.
+func (i *Interceptor) Stream(ctx context.Context, call Call, fn func(context.Context) error) error {
+	return i.intercept(ctx, call, func(ctx context.Context) error {
+		logger.FromContext(ctx).EntryContext(ctx, logger.LevelDebug).Message("stream started")
+		return fn(ctx)
+	}, "stream completed")
+}
+
+func (i *Interceptor) intercept(
+	ctx context.Context, call Call, fn func(context.Context) error, msg string,
+) error {
+	log := i.log.With().
+		String(FieldMethod, call.Method).
+		String(FieldPeer, call.Peer).
+		Span().
+		Logger()
+	ctx = logger.WithContext(ctx, log)
+
+	err := fn(ctx)
+	code, lvl := i.classify(err)
+	entry := log.EntryContext(ctx, lvl).String(FieldCode, code)
+	if err != nil {
+		entry = entry.Error(err)
+	}
+	entry.Message(msg)
+	return err
+}
@@ -0,0 +1,156 @@
+package rpclog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/outsidedigital/logger"
+)
+
+// newTestLogger creates a new logger that writes entries in json format to
+// the returned buffer.
+func newTestLogger() (logger.Logger, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	return logger.NewLogger().With().Writer(logger.JSONWriter(out)).Level(logger.LevelDebug).Logger(), out
+}
+
+// entries decodes json entries written to the buffer.
+func entries(t *testing.T, out *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var ee []map[string]any
+	for dec := json.NewDecoder(out); dec.More(); {
+		var e map[string]any
+		if err := dec.Decode(&e); err != nil {
+			t.Fatalf("invalid entry: %v", err)
+		}
+		ee = append(ee, e)
+	}
+	return ee
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		code  string
+		level logger.Level
+	}{
+		{name: "nil", code: CodeOK, level: logger.LevelInfo},
+		{name: "canceled", err: fmt.Errorf("call: %w", context.Canceled), code: CodeCanceled, level: logger.LevelWarn},
+		{name: "deadline", err: context.DeadlineExceeded, code: CodeDeadlineExceeded, level: logger.LevelWarn},
+		{name: "other", err: errors.New("failed"), code: CodeUnknown, level: logger.LevelError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, lvl := Classify(tt.err)
+			if code != tt.code || lvl != tt.level {
+				t.Errorf("Classify() = %v, %v, want %v, %v", code, lvl, tt.code, tt.level)
+			}
+		})
+	}
+}
+
+func TestInterceptorUnary(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		level string
+		code  string
+	}{
+		{name: "ok", level: "info", code: CodeOK},
+		{name: "error", err: errors.New("failed"), level: "error", code: CodeUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, out := newTestLogger()
+			i := NewInterceptor(log, nil)
+			call := Call{Method: "/svc/Method", Peer: "peer"}
+			err := i.Unary(context.Background(), call, func(ctx context.Context) error {
+				logger.FromContext(ctx).Info().Message("handling")
+				return tt.err
+			})
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Unary() error = %v, want %v", err, tt.err)
+			}
+
+			ee := entries(t, out)
+			if len(ee) != 2 {
+				t.Fatalf("got %d entries, want 2", len(ee))
+			}
+			for _, e := range ee {
+				if e[FieldMethod] != call.Method || e[FieldPeer] != call.Peer {
+					t.Errorf("entry %v lacks call fields", e)
+				}
+			}
+			e := ee[1]
+			if e["level"] != tt.level || e[FieldCode] != tt.code || e["message"] != "call completed" {
+				t.Errorf("entry = %v, want level %v and code %v", e, tt.level, tt.code)
+			}
+			if _, ok := e[logger.FieldError]; ok != (tt.err != nil) {
+				t.Errorf("entry = %v, want error field %v", e, tt.err != nil)
+			}
+		})
+	}
+}
+
+func TestInterceptorStream(t *testing.T) {
+	log, out := newTestLogger()
+	i := NewInterceptor(log, func(err error) (string, logger.Level) {
+		return "custom", logger.LevelWarn
+	})
+	err := i.Stream(context.Background(), Call{Method: "m"}, func(ctx context.Context) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+
+	ee := entries(t, out)
+	if len(ee) != 2 {
+		t.Fatalf("got %d entries, want 2", len(ee))
+	}
+	if ee[0]["level"] != "debug" || ee[0]["message"] != "stream started" {
+		t.Errorf("first entry = %v, want stream start", ee[0])
+	}
+	if ee[1]["level"] != "warn" || ee[1][FieldCode] != "custom" || ee[1]["message"] != "stream completed" {
+		t.Errorf("second entry = %v, want classified stream completion", ee[1])
+	}
+}
+
+func TestInterceptorConcurrent(t *testing.T) {
+	const n = 200
+	out := &bytes.Buffer{}
+	w := logger.NewAsyncWriter(logger.JSONWriter(out), n, logger.OverflowBlock)
+	i := NewInterceptor(logger.NewLogger().With().Writer(w).Logger(), nil)
+
+	var wg sync.WaitGroup
+	for j := 0; j < n; j++ {
+		wg.Add(1)
+		go func(j int) {
+			defer wg.Done()
+			call := Call{Method: fmt.Sprint(j), Peer: fmt.Sprint(j)}
+			_ = i.Unary(context.Background(), call, func(context.Context) error {
+				return nil
+			})
+		}(j)
+	}
+	wg.Wait()
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	ee := entries(t, out)
+	if len(ee) != n {
+		t.Fatalf("got %d entries, want %d", len(ee), n)
+	}
+	for _, e := range ee {
+		if e[FieldMethod] != e[FieldPeer] {
+			t.Errorf("entry %v mixes fields of different calls", e)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package logger
+
+import (
+	"sync"
+	"time"
+)
+
+// Sampler implements a hook that caps the number of entries with the same
+// level and message. Within each interval, it passes the first entries and
+// then every nth one, while the rest are suppressed. Once the interval ends,
+// it writes a summary entry for each suppressed message, even if no more
+// entries are logged. Summaries are written to the writer that received the
+// latest entry, so a sampler must not be shared by loggers with different
+// writers. It's safe for concurrent use.
+type Sampler struct {
+	interval   time.Duration
+	first      uint64
+	thereafter uint64
+	mu         sync.Mutex
+	start      time.Time
+	counts     map[sampleKey]*sampleCount
+	w          Writer
+	timer      *time.Timer
+}
+
+type sampleKey struct {
+	lvl Level
+	msg Message
+}
+
+type sampleCount struct {
+	n          uint64
+	suppressed uint64
+}
+
+// NewSampler creates a new sampling hook that passes the given number of first
+// entries with the same level and message within each interval, and then
+// every thereafter one. If thereafter is zero, the rest entries are suppressed.
+func NewSampler(interval time.Duration, first, thereafter uint64) *Sampler {
+	return &Sampler{
+		interval:   interval,
+		first:      first,
+		thereafter: thereafter,
+		start:      time.Now(),
+		counts:     map[sampleKey]*sampleCount{},
+	}
+}
+
+// Hook intercepts the logging entry and passes it to the writer unless the
+// entry is suppressed.
+func (s *Sampler) Hook(w Writer, ff ...Field) {
+	key := sampleKey{lvl: levelOf(ff), msg: messageOf(ff)}
+	now := time.Now()
+
+	s.mu.Lock()
+	var summary map[sampleKey]*sampleCount
+	if now.Sub(s.start) >= s.interval {
+		summary = s.reset(now)
+	}
+	s.w = w
+	count, ok := s.counts[key]
+	if !ok {
+		count = &sampleCount{}
+		s.counts[key] = count
+	}
+	count.n++
+	pass := count.n <= s.first ||
+		(s.thereafter > 0 && (count.n-s.first)%s.thereafter == 0)
+	if !pass {
+		count.suppressed++
+		if s.timer == nil {
+			s.timer = time.AfterFunc(s.start.Add(s.interval).Sub(now), s.tick)
+		}
+	}
+	s.mu.Unlock()
+
+	reportSampled(w, summary)
+	if pass {
+		w.Write(ff...)
+	}
+}
+
+// Sync writes summary entries for messages suppressed within the current
+// interval and starts a new one.
+func (s *Sampler) Sync() error {
+	s.mu.Lock()
+	summary := s.reset(time.Now())
+	w := s.w
+	s.mu.Unlock()
+
+	if w != nil {
+		reportSampled(w, summary)
+	}
+	return nil
+}
+
+// Close writes summary entries for messages suppressed within the current
+// interval and stops the summary timer.
+func (s *Sampler) Close() error {
+	s.mu.Lock()
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	s.mu.Unlock()
+	return s.Sync()
+}
+
+// tick writes summary entries once the interval with suppressed entries ends.
+func (s *Sampler) tick() {
+	now := time.Now()
+
+	s.mu.Lock()
+	s.timer = nil
+	if d := s.start.Add(s.interval).Sub(now); d > 0 {
+		s.timer = time.AfterFunc(d, s.tick)
+		s.mu.Unlock()
+		return
+	}
+	summary := s.reset(now)
+	w := s.w
+	s.mu.Unlock()
+
+	reportSampled(w, summary)
+}
+
+func (s *Sampler) reset(now time.Time) map[sampleKey]*sampleCount {
+	counts := s.counts
+	s.counts = map[sampleKey]*sampleCount{}
+	s.start = now
+	return counts
+}
+
+// reportSampled writes a summary entry for each message that has suppressed
+// entries.
+func reportSampled(w Writer, summary map[sampleKey]*sampleCount) {
+	for key, count := range summary {
+		if count.suppressed == 0 {
+			continue
+		}
+		w.Write(
+			key.lvl,
+			Group("sampled",
+				String(FieldMessage, string(key.msg)),
+				Uint64("suppressed", count.suppressed),
+			),
+			Message("log entries suppressed by sampling"),
+		)
+	}
+}
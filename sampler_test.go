@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSampler(t *testing.T) {
+	tests := []struct {
+		name       string
+		first      uint64
+		thereafter uint64
+		entries    int
+		passed     int
+		suppressed string
+	}{
+		{name: "below first", first: 5, entries: 5, passed: 5},
+		{name: "drop rest", first: 2, entries: 5, passed: 2, suppressed: `"suppressed":3`},
+		{name: "thereafter", first: 2, thereafter: 3, entries: 11, passed: 5, suppressed: `"suppressed":6`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			s := NewSampler(time.Hour, tt.first, tt.thereafter)
+			log := NewLogger().With().Writer(JSONWriter(out)).Hooks(s).Logger()
+			for i := 0; i < tt.entries; i++ {
+				log.Info().Message("x")
+			}
+			log.Info().Message("y")
+
+			if got := strings.Count(out.String(), `"message":"x"`); got != tt.passed {
+				t.Errorf("passed %d entries, want %d", got, tt.passed)
+			}
+			out.Reset()
+			if err := log.Sync(); err != nil {
+				t.Fatalf("Sync() error = %v", err)
+			}
+			if tt.suppressed == "" {
+				assertOutput(t, out, "")
+				return
+			}
+			if got := out.String(); !strings.Contains(got, `"sampled":{"message":"x",`+tt.suppressed+`}`) {
+				t.Errorf("summary = %s, want %s suppressed", got, tt.suppressed)
+			}
+		})
+	}
+}
+
+func TestSamplerInterval(t *testing.T) {
+	out := &lockedBuffer{}
+	s := NewSampler(20*time.Millisecond, 1, 0)
+	log := NewLogger().With().Writer(JSONWriter(out)).Hooks(s).Logger()
+	defer log.Close()
+
+	for i := 0; i < 3; i++ {
+		log.Info().Message("x")
+	}
+
+	want := `"sampled":{"message":"x","suppressed":2}`
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(out.String(), want) {
+		if time.Now().After(deadline) {
+			t.Fatalf("output = %s, want summary written once the interval ends", out.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	log.Info().Message("x")
+	if got := strings.Count(out.String(), `"message":"x"}`); got != 2 {
+		t.Errorf("passed %d entries, want 2 across intervals", got)
+	}
+}
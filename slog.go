@@ -0,0 +1,132 @@
+//go:build go1.21
+
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// SlogHandler implements a slog handler that outputs to the logger.
+type SlogHandler struct {
+	log Logger
+}
+
+// NewSlogHandler creates a new slog handler that outputs to the given logger.
+func NewSlogHandler(log Logger) *SlogHandler {
+	return &SlogHandler{log: log}
+}
+
+// Enabled checks whether the given slog level is enabled by the logger.
+func (h *SlogHandler) Enabled(_ context.Context, lvl slog.Level) bool {
+	return h.log.Enabled(slogLevel(lvl))
+}
+
+// Handle converts the given slog record into the logging entry and sends it to
+// the logger. The record time, unless it is zero, replaces the logger
+// timestamp. Fields extracted from the given context are appended to the entry.
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	entry := h.log.EntryContext(ctx, slogLevel(r.Level))
+	if !entry.Enabled() {
+		return nil
+	}
+	if !r.Time.IsZero() {
+		entry = withSlogTime(entry, r.Time)
+	}
+	r.Attrs(func(attr slog.Attr) bool {
+		entry.ff = appendSlogAttr(entry.ff, attr)
+		return true
+	})
+	entry.Message(r.Message)
+	return nil
+}
+
+// WithAttrs returns a new handler whose logger contains given attributes.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	var ff []Field
+	for _, attr := range attrs {
+		ff = appendSlogAttr(ff, attr)
+	}
+	return &SlogHandler{log: h.log.With().Fields(ff...).Logger()}
+}
+
+// WithGroup returns a new handler that nests subsequent attributes in the group
+// with the given name.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &SlogHandler{log: h.log.With().Group(name).Logger()}
+}
+
+// withSlogTime replaces the timestamp of the entry with the given time, or
+// appends the time if the entry has no timestamp.
+func withSlogTime(entry Entry, t time.Time) Entry {
+	ff := &entry.ff
+	if len(entry.ns) > 0 {
+		ff = &entry.ns[0].ff
+	}
+	for i, f := range *ff {
+		if _, ok := f.(Timestamp); ok {
+			(*ff)[i] = Time(FieldTime, t)
+			return entry
+		}
+	}
+	*ff = append(*ff, Time(FieldTime, t))
+	return entry
+}
+
+func slogLevel(lvl slog.Level) Level {
+	switch {
+	case lvl >= slog.LevelError:
+		return LevelError
+	case lvl >= slog.LevelWarn:
+		return LevelWarn
+	case lvl >= slog.LevelInfo:
+		return LevelInfo
+	case lvl >= slog.LevelDebug:
+		return LevelDebug
+	default:
+		return LevelTrace
+	}
+}
+
+func appendSlogAttr(ff []Field, attr slog.Attr) []Field {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return ff
+	}
+
+	key := attr.Key
+	switch v := attr.Value; v.Kind() {
+	case slog.KindBool:
+		return append(ff, Bool(key, v.Bool()))
+	case slog.KindDuration:
+		return append(ff, Duration(key, v.Duration()))
+	case slog.KindFloat64:
+		return append(ff, Float64(key, v.Float64()))
+	case slog.KindGroup:
+		var group []Field
+		for _, a := range v.Group() {
+			group = appendSlogAttr(group, a)
+		}
+		if key == "" {
+			return append(ff, group...)
+		}
+		return append(ff, Group(key, group...))
+	case slog.KindInt64:
+		return append(ff, Int64(key, v.Int64()))
+	case slog.KindString:
+		return append(ff, String(key, v.String()))
+	case slog.KindTime:
+		return append(ff, Time(key, v.Time()))
+	case slog.KindUint64:
+		return append(ff, Uint64(key, v.Uint64()))
+	default:
+		return append(ff, Any(key, v.Any()))
+	}
+}
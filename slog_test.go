@@ -0,0 +1,107 @@
+//go:build go1.21
+
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"testing/slogtest"
+	"time"
+)
+
+func TestSlogHandler(t *testing.T) {
+	out := &bytes.Buffer{}
+	log := NewLogger().With().Writer(JSONWriter(out)).Level(LevelTrace).Logger()
+	results := func() []map[string]any {
+		var ee []map[string]any
+		scanner := bufio.NewScanner(bytes.NewReader(out.Bytes()))
+		for scanner.Scan() {
+			var e map[string]any
+			if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+				t.Fatalf("invalid entry %q: %v", scanner.Text(), err)
+			}
+			e[slog.MessageKey] = e[FieldMessage]
+			delete(e, FieldMessage)
+			ee = append(ee, e)
+		}
+		return ee
+	}
+	if err := slogtest.TestHandler(NewSlogHandler(log), results); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSlogHandlerTime(t *testing.T) {
+	tm := time.Date(2022, 4, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		log  func(Logger) Logger
+		time time.Time
+		want string
+	}{
+		{
+			name: "record time",
+			log:  func(log Logger) Logger { return log },
+			time: tm,
+			want: `{"level":"info","time":"2022-04-01T12:00:00Z","message":"x"}`,
+		},
+		{
+			name: "zero time",
+			log:  func(log Logger) Logger { return log },
+			want: `{"level":"info","message":"x"}`,
+		},
+		{
+			name: "replaced timestamp",
+			log:  func(log Logger) Logger { return log.With().Timestamp().String("a", "1").Logger() },
+			time: tm,
+			want: `{"level":"info","time":"2022-04-01T12:00:00Z","a":"1","message":"x"}`,
+		},
+		{
+			name: "group",
+			log:  func(log Logger) Logger { return log.With().Timestamp().Group("g").String("a", "1").Logger() },
+			time: tm,
+			want: `{"level":"info","time":"2022-04-01T12:00:00Z","g":{"a":"1"},"message":"x"}`,
+		},
+		{
+			name: "grouped timestamp",
+			log:  func(log Logger) Logger { return log.With().Group("g").String("a", "1").Timestamp().Logger() },
+			time: tm,
+			want: `{"level":"info","time":"2022-04-01T12:00:00Z","g":{"a":"1"},"message":"x"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, out := newTestLogger()
+			h := NewSlogHandler(tt.log(log))
+			if err := h.Handle(context.Background(), slog.NewRecord(tt.time, slog.LevelInfo, "x", 0)); err != nil {
+				t.Fatal(err)
+			}
+			assertOutput(t, out, tt.want+"\n")
+		})
+	}
+}
+
+func TestSlogHandlerLevels(t *testing.T) {
+	tests := []struct {
+		level slog.Level
+		want  Level
+	}{
+		{level: slog.LevelError + 4, want: LevelError},
+		{level: slog.LevelError, want: LevelError},
+		{level: slog.LevelWarn, want: LevelWarn},
+		{level: slog.LevelInfo, want: LevelInfo},
+		{level: slog.LevelDebug, want: LevelDebug},
+		{level: slog.LevelDebug - 4, want: LevelTrace},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			if got := slogLevel(tt.level); got != tt.want {
+				t.Errorf("slogLevel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
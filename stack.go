@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// stackDepth is a maximum number of frames of automatically captured stack
+// traces.
+const stackDepth = 64
+
+// blockEncoder is an optional interface of the encoder that supports
+// multi-line text blocks.
+type blockEncoder interface {
+	// EncodeBlock encodes a field with the given key and multi-line value.
+	EncodeBlock(key, s string)
+}
+
+// Stack represents a stack trace field and contains program counters of the
+// goroutine frames.
+type Stack []uintptr
+
+// NewStack captures the stack trace of the current goroutine. It omits the
+// given number of frames, where zero identifies the caller of NewStack, and
+// contains at most the given number of frames.
+func NewStack(skip, depth int) Stack {
+	pcs := make([]uintptr, depth)
+	n := runtime.Callers(skip+2, pcs)
+	return pcs[:n]
+}
+
+// Encode encodes the stack trace with the given encoder. Encoders that support
+// multi-line blocks receive the stack trace in text form, the others receive
+// an array of frames, each with a function name, file and line number.
+func (s Stack) Encode(enc Encoder) {
+	if len(s) == 0 {
+		return
+	}
+	if b, ok := enc.(blockEncoder); ok {
+		b.EncodeBlock(FieldStack, s.String())
+		return
+	}
+	enc.EncodeArray(FieldStack, func() {
+		frames := runtime.CallersFrames(s)
+		for {
+			frame, more := frames.Next()
+			enc.EncodeObject("", func() {
+				enc.EncodeString("function", frame.Function)
+				enc.EncodeString("file", frame.File)
+				enc.EncodeInt("line", frame.Line)
+			})
+			if !more {
+				break
+			}
+		}
+	})
+}
+
+// String returns the text form of the stack trace, that contains a function
+// name followed by an indented file and line number for each frame.
+func (s Stack) String() string {
+	var sb strings.Builder
+	frames := runtime.CallersFrames(s)
+	for {
+		frame, more := frames.Next()
+		sb.WriteString(frame.Function)
+		sb.WriteString("\n\t")
+		sb.WriteString(frame.File)
+		sb.WriteByte(':')
+		sb.WriteString(strconv.Itoa(frame.Line))
+		if !more {
+			break
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
@@ -0,0 +1,123 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// stackFrames decodes function names of the stack trace frames from the given
+// json entry.
+func stackFrames(t *testing.T, out *bytes.Buffer) []string {
+	t.Helper()
+	var entry struct {
+		Stack []struct {
+			Function string `json:"function"`
+			File     string `json:"file"`
+			Line     int    `json:"line"`
+		} `json:"stack"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+		t.Fatalf("output = %s, %v", out, err)
+	}
+	out.Reset()
+
+	functions := make([]string, len(entry.Stack))
+	for i, frame := range entry.Stack {
+		if frame.File == "" || frame.Line == 0 {
+			t.Errorf("frame %d = %+v, want file and line", i, frame)
+		}
+		functions[i] = frame.Function
+	}
+	return functions
+}
+
+func TestStack(t *testing.T) {
+	s := NewStack(0, stackDepth)
+	if len(s) == 0 {
+		t.Fatal("NewStack() is empty")
+	}
+	lines := strings.Split(s.String(), "\n")
+	if want := "github.com/outsidedigital/logger.TestStack"; lines[0] != want {
+		t.Errorf("first frame = %s, want %s", lines[0], want)
+	}
+	if len(lines) != 2*len(s) || !strings.HasPrefix(lines[1], "\t") || !strings.Contains(lines[1], "stack_test.go:") {
+		t.Errorf("String() = %s, want indented file and line of each frame", s)
+	}
+	if n := len(NewStack(0, 1)); n != 1 {
+		t.Errorf("NewStack() has %d frames, want 1", n)
+	}
+
+	log, out := newTestLogger()
+	log.Info().With(Stack(nil)).Message("x")
+	assertOutput(t, out, `{"level":"info","message":"x"}`+"\n")
+}
+
+func TestEntryStack(t *testing.T) {
+	tests := []struct {
+		name  string
+		skip  int
+		depth int
+		first string
+	}{
+		{name: "caller", skip: 0, depth: stackDepth, first: "github.com/outsidedigital/logger.TestEntryStack.func1"},
+		{name: "skip", skip: 1, depth: stackDepth, first: "testing.tRunner"},
+		{name: "depth", skip: 0, depth: 1, first: "github.com/outsidedigital/logger.TestEntryStack.func1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, out := newTestLogger()
+			log.Info().Stack(tt.skip, tt.depth).Message("x")
+			frames := stackFrames(t, out)
+			if len(frames) == 0 || frames[0] != tt.first {
+				t.Fatalf("frames = %v, want first %s", frames, tt.first)
+			}
+			if tt.depth < stackDepth && len(frames) != tt.depth {
+				t.Errorf("frames = %v, want %d", frames, tt.depth)
+			}
+		})
+	}
+}
+
+func TestStackLevel(t *testing.T) {
+	tests := []struct {
+		name string
+		log  func(Logger)
+		want bool
+	}{
+		{name: "same level", log: func(log Logger) { log.Error().Message("x") }, want: true},
+		{name: "more severe", log: func(log Logger) { log.Entry(LevelPanic).Message("x") }, want: true},
+		{name: "less severe", log: func(log Logger) { log.Warn().Message("x") }, want: false},
+		{name: "entry", log: func(log Logger) { log.Entry(LevelError).Message("x") }, want: true},
+		{
+			name: "context",
+			log:  func(log Logger) { log.EntryContext(context.Background(), LevelError).Message("x") },
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, out := newTestLogger()
+			log = log.With().StackLevel(LevelError).Logger()
+			tt.log(log)
+			frames := stackFrames(t, out)
+			if !tt.want {
+				if len(frames) != 0 {
+					t.Errorf("frames = %v, want none", frames)
+				}
+				return
+			}
+			if len(frames) == 0 || !strings.HasPrefix(frames[0], "github.com/outsidedigital/logger.TestStackLevel.") {
+				t.Errorf("frames = %v, want the logging function first", frames)
+			}
+		})
+	}
+
+	log, out := newTestLogger()
+	log.Error().Message("x")
+	if frames := stackFrames(t, out); len(frames) != 0 {
+		t.Errorf("frames = %v, want none by default", frames)
+	}
+}
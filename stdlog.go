@@ -0,0 +1,101 @@
+package logger
+
+import (
+	"bytes"
+	stdlog "log"
+	"strings"
+	"sync"
+)
+
+// LineWriter implements an output that splits written bytes into lines and
+// logs each line as a message of the entry. It allows third-party packages
+// that write to io.Writer or log.Logger to output to the logger.
+type LineWriter struct {
+	log   Logger
+	lvl   Level
+	parse bool
+	mu    sync.Mutex
+	buf   []byte
+}
+
+// NewLineWriter creates a new output that logs each written line at the given
+// level. If parse is set, a leading level prefix of the line, such as [WARN] or
+// WARN:, overrides the level and is stripped from the message.
+func NewLineWriter(log Logger, lvl Level, parse bool) *LineWriter {
+	return &LineWriter{log: log, lvl: lvl, parse: parse}
+}
+
+// StdLogger creates a new standard logger that logs each line at the given
+// level. See NewLineWriter for details.
+func StdLogger(log Logger, lvl Level, parse bool) *stdlog.Logger {
+	return stdlog.New(NewLineWriter(log, lvl, parse), "", 0)
+}
+
+// Write logs complete lines of the given bytes and buffers the incomplete one.
+func (w *LineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	start := 0
+	for {
+		i := bytes.IndexByte(w.buf[start:], '\n')
+		if i < 0 {
+			break
+		}
+		w.logLine(w.buf[start : start+i])
+		start += i + 1
+	}
+	w.buf = w.buf[:copy(w.buf, w.buf[start:])]
+	return len(p), nil
+}
+
+// Sync logs the buffered incomplete line.
+func (w *LineWriter) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) > 0 {
+		w.logLine(w.buf)
+		w.buf = w.buf[:0]
+	}
+	return nil
+}
+
+func (w *LineWriter) logLine(line []byte) {
+	line = bytes.TrimRight(line, "\r")
+	if len(line) == 0 {
+		return
+	}
+	lvl, msg := w.lvl, string(line)
+	if w.parse {
+		lvl, msg = parseLevelPrefix(msg, lvl)
+	}
+	w.log.Entry(lvl).Message(msg)
+}
+
+// parseLevelPrefix parses a leading level prefix, such as [WARN] or WARN:, of
+// the given message and returns the level and the rest of the message. If
+// there is no valid prefix, the given level and message are returned.
+func parseLevelPrefix(msg string, lvl Level) (Level, string) {
+	var prefix, rest string
+	if strings.HasPrefix(msg, "[") {
+		end := strings.IndexByte(msg, ']')
+		if end < 0 {
+			return lvl, msg
+		}
+		prefix, rest = msg[1:end], msg[end+1:]
+	} else {
+		end := strings.IndexByte(msg, ':')
+		if end < 0 {
+			return lvl, msg
+		}
+		prefix, rest = msg[:end], msg[end+1:]
+	}
+
+	var parsed Level
+	if err := parsed.UnmarshalText([]byte(prefix)); err != nil || parsed.Equal(LevelNone) {
+		return lvl, msg
+	}
+	return parsed, strings.TrimLeft(rest, " ")
+}
@@ -0,0 +1,81 @@
+package logger
+
+import "testing"
+
+func TestParseLevelPrefix(t *testing.T) {
+	tests := []struct {
+		name    string
+		msg     string
+		wantLvl Level
+		wantMsg string
+	}{
+		{name: "no prefix", msg: "hello", wantLvl: LevelInfo, wantMsg: "hello"},
+		{name: "brackets", msg: "[WARN] hello", wantLvl: LevelWarn, wantMsg: "hello"},
+		{name: "colon", msg: "error: hello", wantLvl: LevelError, wantMsg: "hello"},
+		{name: "alias", msg: "[warning]hello", wantLvl: LevelWarn, wantMsg: "hello"},
+		{name: "unknown level", msg: "[db] hello", wantLvl: LevelInfo, wantMsg: "[db] hello"},
+		{name: "unclosed bracket", msg: "[WARN hello", wantLvl: LevelInfo, wantMsg: "[WARN hello"},
+		{name: "none level", msg: "none: hello", wantLvl: LevelInfo, wantMsg: "none: hello"},
+		{name: "colon later", msg: "hello world: x", wantLvl: LevelInfo, wantMsg: "hello world: x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lvl, msg := parseLevelPrefix(tt.msg, LevelInfo)
+			if lvl != tt.wantLvl || msg != tt.wantMsg {
+				t.Errorf("parseLevelPrefix() = %v, %q, want %v, %q", lvl, msg, tt.wantLvl, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestLineWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		parse  bool
+		writes []string
+		want   string
+		synced string
+	}{
+		{name: "single line", writes: []string{"a\n"}, want: `{"level":"info","message":"a"}` + "\n"},
+		{
+			name:   "multiple lines",
+			writes: []string{"a\nb\n"},
+			want:   `{"level":"info","message":"a"}` + "\n" + `{"level":"info","message":"b"}` + "\n",
+		},
+		{
+			name:   "split line",
+			writes: []string{"a", "b\nc"},
+			want:   `{"level":"info","message":"ab"}` + "\n",
+			synced: `{"level":"info","message":"c"}` + "\n",
+		},
+		{name: "empty lines", writes: []string{"\n\r\n"}, want: ""},
+		{name: "carriage return", writes: []string{"a\r\n"}, want: `{"level":"info","message":"a"}` + "\n"},
+		{name: "prefix ignored", writes: []string{"[WARN] a\n"}, want: `{"level":"info","message":"[WARN] a"}` + "\n"},
+		{name: "prefix parsed", parse: true, writes: []string{"[WARN] a\n"}, want: `{"level":"warn","message":"a"}` + "\n"},
+		{name: "less severe prefix", parse: true, writes: []string{"debug: a\n"}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, out := newTestLogger()
+			w := NewLineWriter(log, LevelInfo, tt.parse)
+			for _, s := range tt.writes {
+				if n, err := w.Write([]byte(s)); n != len(s) || err != nil {
+					t.Fatalf("Write() = %d, %v, want %d, nil", n, err, len(s))
+				}
+			}
+			assertOutput(t, out, tt.want)
+			if err := w.Sync(); err != nil {
+				t.Fatalf("Sync() = %v", err)
+			}
+			assertOutput(t, out, tt.synced)
+		})
+	}
+}
+
+func TestStdLogger(t *testing.T) {
+	log, out := newTestLogger()
+	std := StdLogger(log, LevelWarn, true)
+	std.Print("a")
+	std.Printf("error: %s", "b")
+	assertOutput(t, out, `{"level":"warn","message":"a"}`+"\n"+`{"level":"error","message":"b"}`+"\n")
+}
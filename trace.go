@@ -0,0 +1,145 @@
+package logger
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// TraceID represents a W3C trace identifier field.
+type TraceID [16]byte
+
+// IsValid checks whether the trace identifier is not zero.
+func (id TraceID) IsValid() bool {
+	return id != TraceID{}
+}
+
+// String returns the hexadecimal form of the trace identifier.
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// Encode encodes the trace identifier with the given encoder. Zero trace
+// identifiers are omitted.
+func (id TraceID) Encode(enc Encoder) {
+	if id.IsValid() {
+		enc.EncodeString(FieldTraceID, id.String())
+	}
+}
+
+// SpanID represents a W3C span identifier field.
+type SpanID [8]byte
+
+// IsValid checks whether the span identifier is not zero.
+func (id SpanID) IsValid() bool {
+	return id != SpanID{}
+}
+
+// String returns the hexadecimal form of the span identifier.
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// Encode encodes the span identifier with the given encoder. Zero span
+// identifiers are omitted.
+func (id SpanID) Encode(enc Encoder) {
+	if id.IsValid() {
+		enc.EncodeString(FieldSpanID, id.String())
+	}
+}
+
+// TraceFlags represents a W3C trace flags field.
+type TraceFlags byte
+
+// Sampled checks whether the sampled flag is set.
+func (flags TraceFlags) Sampled() bool {
+	return flags&1 == 1
+}
+
+// String returns the hexadecimal form of the trace flags.
+func (flags TraceFlags) String() string {
+	return hex.EncodeToString([]byte{byte(flags)})
+}
+
+// Encode encodes the trace flags with the given encoder.
+func (flags TraceFlags) Encode(enc Encoder) {
+	enc.EncodeString(FieldTraceFlags, flags.String())
+}
+
+// TraceContext represents a W3C trace context.
+type TraceContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Flags   TraceFlags
+}
+
+// ErrTraceParentInvalid is returned when the traceparent header is invalid.
+var ErrTraceParentInvalid = errors.New("invalid traceparent")
+
+// traceParentLen is a length of the traceparent header of version 00.
+const traceParentLen = 55
+
+// ParseTraceParent parses the value of the W3C traceparent header, such as
+// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01.
+func ParseTraceParent(s string) (TraceContext, error) {
+	var tc TraceContext
+	var version [1]byte
+	valid := len(s) >= traceParentLen &&
+		decodeHex(version[:], s[0:2]) && version[0] != 0xff &&
+		s[2] == '-' && decodeHex(tc.TraceID[:], s[3:35]) &&
+		s[35] == '-' && decodeHex(tc.SpanID[:], s[36:52]) &&
+		s[52] == '-'
+	if valid {
+		var flags [1]byte
+		valid = decodeHex(flags[:], s[53:55])
+		tc.Flags = TraceFlags(flags[0])
+	}
+	if valid && len(s) > traceParentLen {
+		valid = version[0] != 0 && s[traceParentLen] == '-'
+	}
+	if !valid || !tc.TraceID.IsValid() || !tc.SpanID.IsValid() {
+		return TraceContext{}, fmt.Errorf("%s: %w", s, ErrTraceParentInvalid)
+	}
+	return tc, nil
+}
+
+// String returns the traceparent header value of the trace context.
+func (tc TraceContext) String() string {
+	return "00-" + tc.TraceID.String() + "-" + tc.SpanID.String() + "-" + tc.Flags.String()
+}
+
+type traceKey struct{}
+
+// ContextWithTrace returns a copy of the given context that carries the trace
+// context.
+func ContextWithTrace(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, traceKey{}, tc)
+}
+
+// TraceFromContext returns the trace context carried by the given context.
+func TraceFromContext(ctx context.Context) (TraceContext, bool) {
+	tc, ok := ctx.Value(traceKey{}).(TraceContext)
+	return tc, ok
+}
+
+// ContextTrace is a context extractor that extracts the trace identifier, span
+// identifier and trace flags carried by the context.
+func ContextTrace(ctx context.Context) []Field {
+	tc, ok := TraceFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return []Field{tc.TraceID, tc.SpanID, tc.Flags}
+}
+
+// decodeHex decodes the given lowercase hexadecimal string into the bytes.
+func decodeHex(dst []byte, s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	_, err := hex.Decode(dst, []byte(s))
+	return err == nil
+}
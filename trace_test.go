@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	const (
+		traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		spanID  = "00f067aa0ba902b7"
+	)
+	tests := []struct {
+		name    string
+		s       string
+		want    string
+		sampled bool
+		err     error
+	}{
+		{
+			name: "sampled", s: "00-" + traceID + "-" + spanID + "-01",
+			want: "00-" + traceID + "-" + spanID + "-01", sampled: true,
+		},
+		{name: "not sampled", s: "00-" + traceID + "-" + spanID + "-00", want: "00-" + traceID + "-" + spanID + "-00"},
+		{
+			name: "future version", s: "01-" + traceID + "-" + spanID + "-01-extra",
+			want: "00-" + traceID + "-" + spanID + "-01", sampled: true,
+		},
+		{name: "version 00 with extra", s: "00-" + traceID + "-" + spanID + "-01-extra", err: ErrTraceParentInvalid},
+		{name: "invalid version", s: "ff-" + traceID + "-" + spanID + "-01", err: ErrTraceParentInvalid},
+		{name: "uppercase", s: "00-4BF92F3577B34DA6A3CE929D0E0E4736-" + spanID + "-01", err: ErrTraceParentInvalid},
+		{name: "zero trace", s: "00-00000000000000000000000000000000-" + spanID + "-01", err: ErrTraceParentInvalid},
+		{name: "zero span", s: "00-" + traceID + "-0000000000000000-01", err: ErrTraceParentInvalid},
+		{name: "short", s: "00-" + traceID + "-" + spanID, err: ErrTraceParentInvalid},
+		{name: "separator", s: "00_" + traceID + "-" + spanID + "-01", err: ErrTraceParentInvalid},
+		{name: "empty", err: ErrTraceParentInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc, err := ParseTraceParent(tt.s)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ParseTraceParent() error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if got := tc.String(); got != tt.want {
+				t.Errorf("String() = %s, want %s", got, tt.want)
+			}
+			if got := tc.Flags.Sampled(); got != tt.sampled {
+				t.Errorf("Sampled() = %v, want %v", got, tt.sampled)
+			}
+		})
+	}
+}
+
+func TestContextTrace(t *testing.T) {
+	tc, err := ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	log, out := newTestLogger()
+	log = log.With().Extractors(ContextTrace).Logger()
+
+	log.EntryContext(context.Background(), LevelInfo).Message("x")
+	assertOutput(t, out, `{"level":"info","message":"x"}`+"\n")
+
+	ctx := ContextWithTrace(context.Background(), tc)
+	if got, ok := TraceFromContext(ctx); !ok || got != tc {
+		t.Errorf("TraceFromContext() = %v, %v, want %v", got, ok, tc)
+	}
+	log.EntryContext(ctx, LevelInfo).Message("x")
+	assertOutput(t, out, `{"level":"info","trace_id":"4bf92f3577b34da6a3ce929d0e0e4736",`+
+		`"span_id":"00f067aa0ba902b7","trace_flags":"01","message":"x"}`+"\n")
+
+	log.Info().With(TraceID{}, SpanID{}).Message("x")
+	assertOutput(t, out, `{"level":"info","message":"x"}`+"\n")
+}
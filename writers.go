@@ -1,10 +1,16 @@
 package logger
 
 import (
+	"errors"
 	"io"
+	"os"
+	"sync/atomic"
+	"syscall"
 
 	"github.com/outsidedigital/logger/buffer"
+	"github.com/outsidedigital/logger/encoding/console"
 	"github.com/outsidedigital/logger/encoding/json"
+	"github.com/outsidedigital/logger/encoding/logfmt"
 	"github.com/outsidedigital/logger/encoding/text"
 )
 
@@ -22,6 +28,20 @@ func (w WriterFunc) Write(ff ...Field) {
 	w(ff...)
 }
 
+// Syncer is an optional interface of the logging writer or hook that buffers
+// entries.
+type Syncer interface {
+	// Sync flushes buffered entries to the destination.
+	Sync() error
+}
+
+// Closer is an optional interface of the logging writer or hook that holds
+// resources.
+type Closer interface {
+	// Close flushes buffered entries and releases resources.
+	Close() error
+}
+
 // HookWriter creates a wrapper around the given hook that outputs to
 // the specified writer.
 func HookWriter(w Writer, hook Hook) WriterFunc {
@@ -30,40 +50,198 @@ func HookWriter(w Writer, hook Hook) WriterFunc {
 	}
 }
 
-var jsonPool = &buffer.Pool{}
+// Format is a function that encodes given fields into the buffer.
+type Format func(*buffer.Buffer, ...Field)
 
-// JSONWriter creates a new logging writer that encode entries into json format.
-func JSONWriter(out io.Writer) WriterFunc {
-	return func(ff ...Field) {
-		buf := jsonPool.Get()
-		defer jsonPool.Put(buf)
-
-		enc := json.NewEncoder(buf)
-		buf.AppendByte('{')
+// ConsoleFormat returns a format that encodes given fields into the buffer in
+// a human-friendly form. If color is set, levels are colored and the trailing
+// fields are dimmed using ANSI escape codes.
+func ConsoleFormat(color bool) Format {
+	return func(buf *buffer.Buffer, ff ...Field) {
+		enc := console.NewEncoder(buf, color)
 		for _, f := range ff {
 			f.Encode(enc)
 		}
-		buf.AppendString("}\n")
-		buf.WriteTo(out)
+		enc.Flush()
+		if buf.Len() > 0 {
+			buf.AppendByte('\n')
+		}
+	}
+}
+
+// JSONFormat encodes given fields into the buffer in json format.
+func JSONFormat(buf *buffer.Buffer, ff ...Field) {
+	enc := json.NewEncoder(buf)
+	buf.AppendByte('{')
+	for _, f := range ff {
+		f.Encode(enc)
+	}
+	buf.AppendString("}\n")
+}
+
+// LogfmtFormat encodes given fields into the buffer in logfmt format. Nothing
+// is appended to the buffer if fields produce no output.
+func LogfmtFormat(buf *buffer.Buffer, ff ...Field) {
+	enc := logfmt.NewEncoder(buf)
+	for _, f := range ff {
+		f.Encode(enc)
+	}
+	if buf.Len() > 0 {
+		buf.AppendByte('\n')
+	}
+}
+
+// TextFormat encodes given fields into the buffer in text format. Nothing is
+// appended to the buffer if fields produce no output.
+func TextFormat(buf *buffer.Buffer, ff ...Field) {
+	enc := text.NewEncoder(buf)
+	for _, f := range ff {
+		f.Encode(enc)
+	}
+	enc.Flush()
+	if buf.Len() > 0 {
+		buf.AppendByte('\n')
+	}
+}
+
+var writerPool = &buffer.Pool{}
+
+// FormatWriter implements a logging writer that encodes entries with the given
+// format and writes them to the output.
+type FormatWriter struct {
+	failures uint64
+	out      io.Writer
+	format   Format
+	fallback io.Writer
+	onError  func(error)
+}
+
+// WriterOption represents an option of the format writer.
+type WriterOption func(*FormatWriter)
+
+// Fallback sets an output that receives entries which failed to be written to
+// the writer output.
+func Fallback(out io.Writer) WriterOption {
+	return func(w *FormatWriter) {
+		w.fallback = out
+	}
+}
+
+// OnError sets a function that is called with errors returned by the writer
+// output. The function may be called concurrently.
+func OnError(fn func(error)) WriterOption {
+	return func(w *FormatWriter) {
+		w.onError = fn
+	}
+}
+
+// NewFormatWriter creates a new logging writer that encodes entries with the
+// given format and writes them to the given output.
+func NewFormatWriter(out io.Writer, format Format, opts ...WriterOption) *FormatWriter {
+	w := &FormatWriter{out: out, format: format}
+	for _, opt := range opts {
+		opt(w)
 	}
+	return w
 }
 
-var textPool = &buffer.Pool{}
+// ConsoleWriter creates a new logging writer that encode entries into
+// a human-friendly form for development. Colors are enabled only if the output
+// is a terminal and the NO_COLOR environment variable is not set.
+func ConsoleWriter(out io.Writer, opts ...WriterOption) *FormatWriter {
+	return NewFormatWriter(out, ConsoleFormat(isTerminal(out)), opts...)
+}
+
+// JSONWriter creates a new logging writer that encode entries into json format.
+func JSONWriter(out io.Writer, opts ...WriterOption) *FormatWriter {
+	return NewFormatWriter(out, JSONFormat, opts...)
+}
+
+// LogfmtWriter creates a new logging writer that encode entries into logfmt
+// format.
+func LogfmtWriter(out io.Writer, opts ...WriterOption) *FormatWriter {
+	return NewFormatWriter(out, LogfmtFormat, opts...)
+}
 
 // TextWriter creates a new logging writer that encode entries into text format.
-func TextWriter(out io.Writer) WriterFunc {
-	return func(ff ...Field) {
-		buf := textPool.Get()
-		defer textPool.Put(buf)
+func TextWriter(out io.Writer, opts ...WriterOption) *FormatWriter {
+	return NewFormatWriter(out, TextFormat, opts...)
+}
 
-		enc := text.NewEncoder(buf)
-		for _, f := range ff {
-			f.Encode(enc)
-		}
+// Write encodes given fields and writes them to the output.
+func (w *FormatWriter) Write(ff ...Field) {
+	buf := writerPool.Get()
+	defer writerPool.Put(buf)
 
-		if buf.Len() > 0 {
-			buf.AppendByte('\n')
-			buf.WriteTo(out)
+	w.format(buf, ff...)
+	w.write(buf)
+}
+
+// Sync commits the output contents to the stable storage, if the output
+// supports it. Errors of outputs that can't be synced, such as terminals and
+// pipes, are ignored.
+func (w *FormatWriter) Sync() error {
+	s, ok := w.out.(Syncer)
+	if !ok {
+		return nil
+	}
+	err := s.Sync()
+	if errors.Is(err, syscall.EINVAL) || errors.Is(err, syscall.ENOTSUP) {
+		return nil
+	}
+	return err
+}
+
+// Close syncs and closes the output, if the output supports it. Standard
+// output streams are never closed.
+func (w *FormatWriter) Close() error {
+	err := w.Sync()
+	if w.out == os.Stdout || w.out == os.Stderr {
+		return err
+	}
+	if c, ok := w.out.(io.Closer); ok {
+		if cerr := c.Close(); err == nil {
+			err = cerr
 		}
 	}
+	return err
+}
+
+// Failures returns the number of entries that failed to be written to the
+// output.
+func (w *FormatWriter) Failures() uint64 {
+	return atomic.LoadUint64(&w.failures)
+}
+
+func (w *FormatWriter) write(buf *buffer.Buffer) {
+	if buf.Len() == 0 {
+		return
+	}
+	n, err := buf.WriteTo(w.out)
+	if err == nil && n < int64(buf.Len()) {
+		err = io.ErrShortWrite
+	}
+	if err == nil {
+		return
+	}
+
+	atomic.AddUint64(&w.failures, 1)
+	if w.onError != nil {
+		w.onError(err)
+	}
+	if w.fallback != nil {
+		buf.WriteTo(w.fallback)
+	}
+}
+
+func isTerminal(out io.Writer) bool {
+	if v, ok := os.LookupEnv("NO_COLOR"); ok && v != "" {
+		return false
+	}
+	f, ok := out.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
 }
@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// failingWriter implements an output that writes the given number of bytes
+// and returns the given error.
+type failingWriter struct {
+	n   int
+	err error
+}
+
+func (w failingWriter) Write(p []byte) (int, error) {
+	if w.n >= 0 && w.n < len(p) {
+		return w.n, w.err
+	}
+	return len(p), w.err
+}
+
+func TestFormatWriterErrors(t *testing.T) {
+	errWrite := errors.New("write failed")
+	tests := []struct {
+		name     string
+		out      io.Writer
+		err      error
+		failures uint64
+	}{
+		{name: "ok", out: failingWriter{n: -1}},
+		{name: "error", out: failingWriter{n: -1, err: errWrite}, err: errWrite, failures: 1},
+		{name: "short write", out: failingWriter{n: 1}, err: io.ErrShortWrite, failures: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errs []error
+			fallback := &bytes.Buffer{}
+			w := TextWriter(tt.out, Fallback(fallback), OnError(func(err error) {
+				errs = append(errs, err)
+			}))
+			w.Write(Message("x"))
+			w.Write()
+
+			if got := w.Failures(); got != tt.failures {
+				t.Errorf("Failures() = %d, want %d", got, tt.failures)
+			}
+			if tt.err == nil {
+				if len(errs) != 0 {
+					t.Errorf("reported errors %v, want none", errs)
+				}
+				assertOutput(t, fallback, "")
+				return
+			}
+			if len(errs) != 1 || !errors.Is(errs[0], tt.err) {
+				t.Errorf("reported errors %v, want %v", errs, tt.err)
+			}
+			assertOutput(t, fallback, "message=x\n")
+		})
+	}
+}
+
+func TestFormatWriterClose(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := JSONWriter(f)
+	w.Write(Message("x"))
+	if err := w.Sync(); err != nil {
+		t.Errorf("Sync() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if _, err := f.Write(nil); err == nil {
+		t.Error("output is not closed")
+	}
+
+	for _, out := range []*os.File{os.Stdout, os.Stderr} {
+		if err := NewFormatWriter(out, JSONFormat).Close(); err != nil {
+			t.Errorf("Close() of %s error = %v", out.Name(), err)
+		}
+		if _, err := out.Stat(); err != nil {
+			t.Errorf("%s is closed", out.Name())
+		}
+	}
+}
+
+func TestFormatWriterSyncUnsupported(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	if err := TextWriter(w).Sync(); err != nil {
+		t.Errorf("Sync() of pipe error = %v, want nil", err)
+	}
+}